@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"spicytodo-go-api/handlers"
 	"spicytodo-go-api/models"
 	"spicytodo-go-api/routes"
@@ -14,20 +15,25 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func setupBenchmark() *gin.Engine {
+// setupBenchmark wires up a router against a TodoService whose event log
+// is a private temp file, so this benchmark can't leak state into (or
+// read state left by) another test via the shared default
+// data/events.log path.
+func setupBenchmark(b *testing.B) *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
-	
+	b.Setenv("EVENTS_LOG_PATH", filepath.Join(b.TempDir(), "events.log"))
+
 	todoService := service.NewTodoService()
 	todoHandler := handlers.NewTodoHandler(todoService)
-	
+
 	router := gin.New() // Don't use Default to avoid logging overhead
 	routes.SetupRoutes(router, todoHandler)
-	
+
 	return router
 }
 
 func BenchmarkGetAllTodos(b *testing.B) {
-	router := setupBenchmark()
+	router := setupBenchmark(b)
 	req, _ := http.NewRequest("GET", "/api/todos", nil)
 
 	b.ResetTimer()
@@ -38,7 +44,7 @@ func BenchmarkGetAllTodos(b *testing.B) {
 }
 
 func BenchmarkGetTodoByID(b *testing.B) {
-	router := setupBenchmark()
+	router := setupBenchmark(b)
 	
 	// Create a todo first
 	createBody, _ := json.Marshal(models.TodoCreate{Text: "Benchmark"})
@@ -60,7 +66,7 @@ func BenchmarkGetTodoByID(b *testing.B) {
 }
 
 func BenchmarkCreateTodo(b *testing.B) {
-	router := setupBenchmark()
+	router := setupBenchmark(b)
 	
 	body, _ := json.Marshal(models.TodoCreate{
 		Text:     "Benchmark Todo",
@@ -77,7 +83,7 @@ func BenchmarkCreateTodo(b *testing.B) {
 }
 
 func BenchmarkUpdateTodo(b *testing.B) {
-	router := setupBenchmark()
+	router := setupBenchmark(b)
 	
 	// Create a todo first
 	createBody, _ := json.Marshal(models.TodoCreate{Text: "Benchmark"})
@@ -102,7 +108,7 @@ func BenchmarkUpdateTodo(b *testing.B) {
 }
 
 func BenchmarkToggleTodo(b *testing.B) {
-	router := setupBenchmark()
+	router := setupBenchmark(b)
 	
 	// Create a todo first
 	createBody, _ := json.Marshal(models.TodoCreate{Text: "Benchmark"})
@@ -123,7 +129,7 @@ func BenchmarkToggleTodo(b *testing.B) {
 }
 
 func BenchmarkGetStats(b *testing.B) {
-	router := setupBenchmark()
+	router := setupBenchmark(b)
 	req, _ := http.NewRequest("GET", "/api/todos/stats/summary", nil)
 
 	b.ResetTimer()
@@ -134,7 +140,7 @@ func BenchmarkGetStats(b *testing.B) {
 }
 
 func BenchmarkGetByTag(b *testing.B) {
-	router := setupBenchmark()
+	router := setupBenchmark(b)
 	req, _ := http.NewRequest("GET", "/api/todos/tags/work", nil)
 
 	b.ResetTimer()
@@ -145,7 +151,7 @@ func BenchmarkGetByTag(b *testing.B) {
 }
 
 func BenchmarkBulkComplete(b *testing.B) {
-	router := setupBenchmark()
+	router := setupBenchmark(b)
 	
 	bulkBody, _ := json.Marshal(models.BulkOperation{
 		IDs:       []string{"123", "456", "789"},
@@ -162,7 +168,7 @@ func BenchmarkBulkComplete(b *testing.B) {
 }
 
 func BenchmarkExportTodos(b *testing.B) {
-	router := setupBenchmark()
+	router := setupBenchmark(b)
 	req, _ := http.NewRequest("GET", "/api/export/todos", nil)
 
 	b.ResetTimer()
@@ -173,7 +179,7 @@ func BenchmarkExportTodos(b *testing.B) {
 }
 
 func BenchmarkGetAllTodosWithFilter(b *testing.B) {
-	router := setupBenchmark()
+	router := setupBenchmark(b)
 	req, _ := http.NewRequest("GET", "/api/todos?filter=active&priority=high", nil)
 
 	b.ResetTimer()
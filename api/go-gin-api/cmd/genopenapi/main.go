@@ -0,0 +1,43 @@
+// Command genopenapi writes the API's OpenAPI 3 spec to api/openapi.yaml by
+// standing up the real service/handler/route wiring (without binding a
+// listening port) and building the document from its actual route table.
+//
+//go:generate go run .
+package main
+
+import (
+	"log"
+	"os"
+	"spicytodo-go-api/handlers"
+	"spicytodo-go-api/openapi"
+	"spicytodo-go-api/routes"
+	"spicytodo-go-api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+const outputPath = "../../openapi.yaml"
+
+func main() {
+	gin.SetMode(gin.ReleaseMode)
+
+	todoService := service.NewTodoService()
+	todoHandler := handlers.NewTodoHandler(todoService)
+
+	router := gin.New()
+	routes.SetupRoutes(router, todoHandler)
+
+	doc := openapi.Build("Spicy Todo API", "1.0.0", router.Routes(), openapi.NamedSchemas(), openapi.RouteTable())
+
+	out, err := os.Create(outputPath)
+	if err != nil {
+		log.Fatalf("genopenapi: creating %s: %v", outputPath, err)
+	}
+	defer out.Close()
+
+	if err := openapi.WriteYAML(out, doc); err != nil {
+		log.Fatalf("genopenapi: writing %s: %v", outputPath, err)
+	}
+
+	log.Printf("genopenapi: wrote %s", outputPath)
+}
@@ -0,0 +1,70 @@
+// Package events defines the typed events recorded to the TodoService's
+// append-only event log, and the envelope used to serialize them as
+// newline-delimited JSON.
+package events
+
+import (
+	"encoding/json"
+	"spicytodo-go-api/models"
+	"time"
+)
+
+const (
+	TypeTodoCreated   = "TodoCreated"
+	TypeTodoUpdated   = "TodoUpdated"
+	TypeTodoDeleted   = "TodoDeleted"
+	TypeTodoCompleted = "TodoCompleted"
+	TypeTodoSnoozed   = "TodoSnoozed"
+	TypeBulkOp        = "BulkOp"
+	TypeSnapshot      = "Snapshot"
+)
+
+// Record is the on-disk envelope for one logged event: one JSON object per
+// line, in append order. Seq is monotonically increasing and is what
+// `GET /api/events?since=` callers resume from.
+type Record struct {
+	Seq  uint64          `json:"seq"`
+	Type string          `json:"type"`
+	At   time.Time       `json:"at"`
+	Data json.RawMessage `json:"data"`
+}
+
+// TodoCreated carries the full state of a newly created todo.
+type TodoCreated struct {
+	Todo models.Todo `json:"todo"`
+}
+
+// TodoUpdated carries the full post-update state of a todo.
+type TodoUpdated struct {
+	Todo models.Todo `json:"todo"`
+}
+
+// TodoDeleted identifies a removed todo by ID.
+type TodoDeleted struct {
+	ID string `json:"id"`
+}
+
+// TodoCompleted records a completion-state change, e.g. from Toggle.
+type TodoCompleted struct {
+	ID        string `json:"id"`
+	Completed bool   `json:"completed"`
+}
+
+// TodoSnoozed records a snooze or unsnooze (Until is nil for unsnooze).
+type TodoSnoozed struct {
+	ID    string     `json:"id"`
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// BulkOp records a bulk mutation applied to a set of todo IDs.
+type BulkOp struct {
+	Operation string   `json:"operation"`
+	IDs       []string `json:"ids"`
+	Priority  string   `json:"priority,omitempty"`
+}
+
+// Snapshot carries a full state dump, written periodically to bound replay
+// time; it is always the first record applied during replay when present.
+type Snapshot struct {
+	Todos []models.Todo `json:"todos"`
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetTodoHistory returns the sequence of recorded events for a single todo,
+// for clients that want to see how it got to its current state.
+func (h *TodoHandler) GetTodoHistory(c *gin.Context) error {
+	id := c.Param("id")
+
+	history, err := h.service.History(id)
+	if err != nil {
+		return err
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": id, "history": history})
+	return nil
+}
+
+// UndoTodo reverts a todo to the state captured by its last full snapshot in
+// the event log. See TodoService.Undo for exactly what "reverts" means.
+func (h *TodoHandler) UndoTodo(c *gin.Context) error {
+	id := c.Param("id")
+
+	todo, ok := h.service.Undo(id)
+	if !ok {
+		return notFound("Nothing to undo for this todo")
+	}
+
+	c.JSON(http.StatusOK, todo)
+	return nil
+}
+
+// GetSchedulerPending returns the scheduler's next pending reminder and
+// snooze-expiry fires, soonest first, for observability. Accepts `?n=` to
+// cap how many are returned (default 20).
+func (h *TodoHandler) GetSchedulerPending(c *gin.Context) error {
+	n, err := strconv.Atoi(c.DefaultQuery("n", "20"))
+	if err != nil || n < 0 {
+		n = 20
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pending": h.service.SchedulerPending(n)})
+	return nil
+}
+
+// ReplayEvents rebuilds the in-memory projection from scratch by replaying
+// the event log, for operators who suspect the live state has drifted.
+func (h *TodoHandler) ReplayEvents(c *gin.Context) error {
+	applied, err := h.service.Rebuild()
+	if err != nil {
+		return err
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Projection rebuilt from event log", "applied": applied})
+	return nil
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+	"spicytodo-go-api/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAgents lists every registered agent.
+func (h *TodoHandler) GetAgents(c *gin.Context) error {
+	c.JSON(http.StatusOK, h.service.ListAgents())
+	return nil
+}
+
+// CreateAgent registers (or replaces) an agent that todos can be assigned
+// to.
+func (h *TodoHandler) CreateAgent(c *gin.Context) error {
+	var input models.AgentCreate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		return validationError(err.Error())
+	}
+
+	agent := h.service.RegisterAgent(input)
+	c.JSON(http.StatusCreated, agent)
+	return nil
+}
+
+// AssignTodo assigns a todo to its best-matching registered agent, scored
+// by how well the agent's labels cover the todo's own.
+func (h *TodoHandler) AssignTodo(c *gin.Context) error {
+	id := c.Param("id")
+
+	todo, ok := h.service.AssignTodo(id)
+	if todo == nil {
+		return notFound("Todo not found")
+	}
+	if !ok {
+		return validationError("No registered agent matches this todo's labels")
+	}
+
+	c.JSON(http.StatusOK, todo)
+	return nil
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PullCalDAV fetches every VTODO from the configured CalDAV server and
+// reconciles the local store by UID. See TodoService.PullCalDAV for the
+// reconciliation semantics.
+func (h *TodoHandler) PullCalDAV(c *gin.Context) error {
+	if !h.service.CalDAVConfigured() {
+		return validationError("CalDAV sync is not configured")
+	}
+
+	pulled, err := h.service.PullCalDAV()
+	if err != nil {
+		return err
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pulled": pulled})
+	return nil
+}
+
+// PushCalDAV synchronously pushes every local todo's current state to the
+// configured CalDAV server, e.g. to backfill a calendar that was empty (or
+// reset) without waiting for each todo to be mutated again.
+func (h *TodoHandler) PushCalDAV(c *gin.Context) error {
+	if !h.service.CalDAVConfigured() {
+		return validationError("CalDAV sync is not configured")
+	}
+
+	pushed, err := h.service.PushAllCalDAV()
+	if err != nil {
+		return err
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pushed": pushed})
+	return nil
+}
+
+// SyncCalDAV runs a full two-way sync against the configured CalDAV
+// server: pull first, then push. See TodoService.SyncCalDAV.
+func (h *TodoHandler) SyncCalDAV(c *gin.Context) error {
+	if !h.service.CalDAVConfigured() {
+		return validationError("CalDAV sync is not configured")
+	}
+
+	pulled, pushed, err := h.service.SyncCalDAV()
+	if err != nil {
+		return err
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pulled": pulled, "pushed": pushed})
+	return nil
+}
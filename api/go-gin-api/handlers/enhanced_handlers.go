@@ -1,114 +1,259 @@
 package handlers
 
 import (
+	"bytes"
+	"fmt"
 	"net/http"
 	"spicytodo-go-api/models"
+	"spicytodo-go-api/service"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 // Snooze a todo
-func (h *TodoHandler) SnoozeTodo(c *gin.Context) {
+func (h *TodoHandler) SnoozeTodo(c *gin.Context) error {
 	id := c.Param("id")
 
 	var input models.SnoozeRequest
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
+		return validationError(err.Error())
 	}
 
 	todo, exists := h.service.Snooze(id, input.Until)
 	if !exists {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Todo not found"})
-		return
+		return notFound("Todo not found")
 	}
 
 	c.JSON(http.StatusOK, todo)
+	return nil
 }
 
 // Unsnooze a todo
-func (h *TodoHandler) UnsnoozeTodo(c *gin.Context) {
+func (h *TodoHandler) UnsnoozeTodo(c *gin.Context) error {
 	id := c.Param("id")
 
 	todo, exists := h.service.Unsnooze(id)
 	if !exists {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Todo not found"})
-		return
+		return notFound("Todo not found")
 	}
 
 	c.JSON(http.StatusOK, todo)
+	return nil
 }
 
-// GetUpcomingReminders returns todos with upcoming reminders
-func (h *TodoHandler) GetUpcomingReminders(c *gin.Context) {
-	reminders := h.service.GetUpcomingReminders()
+// SetTodoRecurrence sets a todo's recurrence rule.
+func (h *TodoHandler) SetTodoRecurrence(c *gin.Context) error {
+	id := c.Param("id")
+
+	var input models.RecurrenceRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		return validationError(err.Error())
+	}
+
+	todo, exists := h.service.SetRecurrence(id, input.RecurrenceRule, input.Recurrence)
+	if !exists {
+		return notFound("Todo not found")
+	}
+
+	c.JSON(http.StatusOK, todo)
+	return nil
+}
+
+// ClearTodoRecurrence stops a todo from spawning further occurrences.
+func (h *TodoHandler) ClearTodoRecurrence(c *gin.Context) error {
+	id := c.Param("id")
+
+	todo, exists := h.service.ClearRecurrence(id)
+	if !exists {
+		return notFound("Todo not found")
+	}
+
+	c.JSON(http.StatusOK, todo)
+	return nil
+}
+
+// GetUpcomingReminders returns todos with a reminder due in the requested
+// window. `from`/`to` (RFC3339 or unix seconds) default to "next 24 hours"
+// when omitted; `include_snoozed=true` also surfaces todos whose
+// SnoozedUntil falls inside the window.
+func (h *TodoHandler) GetUpcomingReminders(c *gin.Context) error {
+	window, err := parseReminderWindow(c)
+	if err != nil {
+		return validationError(err.Error())
+	}
+
+	reminders := h.service.GetUpcomingReminders(window)
 	c.JSON(http.StatusOK, reminders)
+	return nil
+}
+
+// GetTodoOccurrences expands a recurring todo's rule into its virtual
+// occurrence dates within [from, to] (both RFC3339 or unix seconds,
+// defaulting to [now, now+30d]) without persisting any of them. See
+// TodoService.OccurrencesBetween.
+func (h *TodoHandler) GetTodoOccurrences(c *gin.Context) error {
+	id := c.Param("id")
+	todo, exists := h.service.GetByID(id)
+	if !exists {
+		return notFound("Todo not found")
+	}
+
+	from := time.Now()
+	if raw := c.Query("from"); raw != "" {
+		t, err := parseWindowTime(raw)
+		if err != nil {
+			return validationError(fmt.Sprintf("invalid from: %v", err))
+		}
+		from = t
+	}
+
+	to := from.AddDate(0, 0, 30)
+	if raw := c.Query("to"); raw != "" {
+		t, err := parseWindowTime(raw)
+		if err != nil {
+			return validationError(fmt.Sprintf("invalid to: %v", err))
+		}
+		to = t
+	}
+
+	occurrences := h.service.OccurrencesBetween(todo, from, to)
+	c.JSON(http.StatusOK, gin.H{"occurrences": occurrences})
+	return nil
+}
+
+// parseReminderWindow reads `from`/`to` (RFC3339 or unix seconds) and
+// `include_snoozed` into a models.ReminderWindow and applies its defaults.
+func parseReminderWindow(c *gin.Context) (models.ReminderWindow, error) {
+	var window models.ReminderWindow
+
+	if raw := c.Query("from"); raw != "" {
+		t, err := parseWindowTime(raw)
+		if err != nil {
+			return window, fmt.Errorf("invalid from: %w", err)
+		}
+		window.From = t
+	}
+	if raw := c.Query("to"); raw != "" {
+		t, err := parseWindowTime(raw)
+		if err != nil {
+			return window, fmt.Errorf("invalid to: %w", err)
+		}
+		window.To = t
+	}
+	window.IncludeSnoozed = c.Query("include_snoozed") == "true"
+
+	if err := window.SetDefaults(time.Now()); err != nil {
+		return window, err
+	}
+	return window, nil
+}
+
+// parseWindowTime accepts either RFC3339 or unix seconds, matching the
+// other timestamp query params across this API.
+func parseWindowTime(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	if secs, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return time.Unix(secs, 0), nil
+	}
+	return time.Time{}, fmt.Errorf("must be RFC3339 or unix seconds: %q", raw)
+}
+
+// SearchTodos runs a query against the service's full-text search index.
+// `q` is required; `limit` caps the number of results (0/absent = no cap).
+// See service.searchIndex.eval for the query grammar.
+func (h *TodoHandler) SearchTodos(c *gin.Context) error {
+	query := c.Query("q")
+	if query == "" {
+		return validationError("q is required")
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	todos := h.service.Search(query, service.SearchOptions{Limit: limit})
+	c.JSON(http.StatusOK, todos)
+	return nil
 }
 
 // GetByTag returns todos with a specific tag
-func (h *TodoHandler) GetByTag(c *gin.Context) {
+func (h *TodoHandler) GetByTag(c *gin.Context) error {
 	tag := c.Param("tag")
 	todos := h.service.GetByTag(tag)
 	c.JSON(http.StatusOK, todos)
+	return nil
 }
 
 // GetByCategory returns todos in a specific category
-func (h *TodoHandler) GetByCategory(c *gin.Context) {
+func (h *TodoHandler) GetByCategory(c *gin.Context) error {
 	category := c.Param("category")
 	todos := h.service.GetByCategory(category)
 	c.JSON(http.StatusOK, todos)
+	return nil
 }
 
 // GetAllTags returns all unique tags
-func (h *TodoHandler) GetAllTags(c *gin.Context) {
+func (h *TodoHandler) GetAllTags(c *gin.Context) error {
 	tags := h.service.GetAllTags()
 	c.JSON(http.StatusOK, gin.H{"tags": tags})
+	return nil
 }
 
 // GetAllCategories returns all unique categories
-func (h *TodoHandler) GetAllCategories(c *gin.Context) {
+func (h *TodoHandler) GetAllCategories(c *gin.Context) error {
 	categories := h.service.GetAllCategories()
 	c.JSON(http.StatusOK, gin.H{"categories": categories})
+	return nil
 }
 
-// BulkOperation handles bulk operations on todos
-func (h *TodoHandler) BulkOperation(c *gin.Context) {
+// BulkOperation handles bulk operations on todos. Large ID lists are
+// processed item-by-item against the request context, so a client
+// disconnect or the deadline middleware (see middleware/deadline.go) can
+// abort the operation partway through.
+func (h *TodoHandler) BulkOperation(c *gin.Context) error {
 	var input models.BulkOperation
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
+		return validationError(err.Error())
 	}
 
+	ctx := c.Request.Context()
 	var affected int
+	var cancelled bool
 
 	switch input.Operation {
 	case "delete":
-		affected = h.service.BulkDelete(input.IDs)
-		
+		affected, cancelled = h.service.BulkDelete(ctx, input.IDs)
+
 	case "complete":
-		affected = h.service.BulkComplete(input.IDs, true)
-		
+		affected, cancelled = h.service.BulkComplete(ctx, input.IDs, true)
+
 	case "uncomplete":
-		affected = h.service.BulkComplete(input.IDs, false)
-		
+		affected, cancelled = h.service.BulkComplete(ctx, input.IDs, false)
+
 	case "updatePriority":
 		if priorityStr, ok := input.Data["priority"].(string); ok {
 			priority := models.Priority(priorityStr)
-			affected = h.service.BulkUpdatePriority(input.IDs, priority)
+			affected, cancelled = h.service.BulkUpdatePriority(ctx, input.IDs, priority)
 		} else {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Priority required for updatePriority operation"})
-			return
+			return validationError("Priority required for updatePriority operation")
 		}
-		
+
 	default:
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation. Valid: delete, complete, uncomplete, updatePriority"})
-		return
+		return validationError("Invalid operation. Valid: delete, complete, uncomplete, updatePriority")
+	}
+
+	if cancelled {
+		return bulkCancelled(ctx, affected)
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Bulk operation completed",
+		"message":  "Bulk operation completed",
 		"affected": affected,
 	})
+	return nil
 }
 
 // ExportTodos exports todos in JSON format
@@ -127,44 +272,162 @@ func (h *TodoHandler) ExportTodos(c *gin.Context) {
 	}
 
 	result := h.service.ExportTodos(query.Filter)
+	result.Format = query.Format
 
-	// Set download headers
-	filename := "todos_" + query.Filter + "_" + result.ExportedAt[:10] + ".json"
-	c.Header("Content-Type", "application/json")
-	c.Header("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	datePart := result.ExportedAt[:10]
 
-	c.JSON(http.StatusOK, result)
-}
+	switch query.Format {
+	case "csv":
+		filename := "todos_" + query.Filter + "_" + datePart + ".csv"
+		var buf bytes.Buffer
+		service.EncodeCSVTo(&buf, result.Data)
+		c.Header("Content-Disposition", "attachment; filename=\""+filename+"\"")
+		c.Data(http.StatusOK, "text/csv", buf.Bytes())
 
-// ImportTodos imports todos from JSON
-func (h *TodoHandler) ImportTodos(c *gin.Context) {
-	var input models.ImportRequest
-	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
+	case "ndjson":
+		filename := "todos_" + query.Filter + "_" + datePart + ".ndjson"
+		var buf bytes.Buffer
+		service.EncodeNDJSONTo(&buf, result.Data)
+		c.Header("Content-Disposition", "attachment; filename=\""+filename+"\"")
+		c.Data(http.StatusOK, "application/x-ndjson", buf.Bytes())
+
+	case "md":
+		data := service.EncodeMarkdown(result.Data)
+		filename := "todos_" + query.Filter + "_" + datePart + ".md"
+		c.Header("Content-Type", "text/markdown")
+		c.Header("Content-Disposition", "attachment; filename=\""+filename+"\"")
+		c.String(http.StatusOK, data)
+
+	case "ics", "ical":
+		filename := "todos_" + query.Filter + "_" + datePart + ".ics"
+		var buf bytes.Buffer
+		service.EncodeICSTo(&buf, result.Data)
+		c.Header("Content-Disposition", "attachment; filename=\""+filename+"\"")
+		c.Data(http.StatusOK, "text/calendar", buf.Bytes())
+
+	default:
+		filename := "todos_" + query.Filter + "_" + datePart + ".json"
+		c.Header("Content-Type", "application/json")
+		c.Header("Content-Disposition", "attachment; filename=\""+filename+"\"")
+		c.JSON(http.StatusOK, result)
 	}
+}
+
+// ImportTodos imports todos from JSON (default), or from CSV/NDJSON/Markdown/
+// iCalendar when `?format=csv`, `?format=ndjson`, `?format=md`, or
+// `?format=ics` (alias `ical`) is given, in which case the request body is
+// the raw document rather than a JSON ImportRequest. CSV additionally
+// accepts `columns` (a "header:field,..." mapping, see
+// service.CSVColumnMapping) and `tagSep` (defaults to ";") for files that
+// don't use EncodeCSV's own header names.
+func (h *TodoHandler) ImportTodos(c *gin.Context) error {
+	format := c.Query("format")
+	mode := c.DefaultQuery("mode", "append")
 
-	// Default mode
-	if input.Mode == "" {
-		input.Mode = "append"
+	var todos []models.TodoCreate
+	var formatErrors []string
+
+	switch format {
+	case "csv":
+		body, err := c.GetRawData()
+		if err != nil {
+			return validationError(err.Error())
+		}
+		mapping := parseCSVColumnMapping(c.Query("columns"))
+		todos, formatErrors = service.DecodeCSV(bytes.NewReader(body), mapping, c.Query("tagSep"))
+
+	case "ndjson":
+		body, err := c.GetRawData()
+		if err != nil {
+			return validationError(err.Error())
+		}
+		todos, formatErrors = service.DecodeNDJSON(bytes.NewReader(body))
+
+	case "md", "markdown":
+		body, err := c.GetRawData()
+		if err != nil {
+			return validationError(err.Error())
+		}
+		todos, formatErrors = service.DecodeMarkdown(bytes.NewReader(body))
+
+	case "ics", "ical":
+		body, err := c.GetRawData()
+		if err != nil {
+			return validationError(err.Error())
+		}
+		todos, formatErrors = service.DecodeICS(bytes.NewReader(body))
+
+	default:
+		var input models.ImportRequest
+		if err := c.ShouldBindJSON(&input); err != nil {
+			return validationError(err.Error())
+		}
+		todos = input.Todos
+		if input.Mode != "" {
+			mode = input.Mode
+		}
 	}
 
 	// Validate mode
 	validModes := []string{"replace", "append"}
 	isValidMode := false
-	for _, mode := range validModes {
-		if input.Mode == mode {
+	for _, m := range validModes {
+		if mode == m {
 			isValidMode = true
 			break
 		}
 	}
 
 	if !isValidMode {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mode. Valid modes: replace, append"})
-		return
+		return validationError("Invalid mode. Valid modes: replace, append")
 	}
 
-	result := h.service.ImportTodos(input.Todos, input.Mode)
+	result := h.service.ImportTodos(c.Request.Context(), todos, mode)
+	if result.Cancelled {
+		return bulkCancelled(c.Request.Context(), result.Imported)
+	}
+	result.Errors = append(formatErrors, result.Errors...)
+	result.Skipped += len(formatErrors)
 	c.JSON(http.StatusOK, result)
+	return nil
 }
 
+// parseCSVColumnMapping parses a "header:field,header:field" query value
+// (e.g. "note:text,deadline:duedate,labels:tags") into a
+// service.CSVColumnMapping. An empty raw value returns nil so DecodeCSV
+// falls back to service.DefaultCSVColumnMapping. Malformed pairs are
+// skipped rather than rejected outright.
+func parseCSVColumnMapping(raw string) service.CSVColumnMapping {
+	if raw == "" {
+		return nil
+	}
+
+	mapping := service.CSVColumnMapping{}
+	for _, pair := range strings.Split(raw, ",") {
+		kv := strings.SplitN(pair, ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		header := strings.ToLower(strings.TrimSpace(kv[0]))
+		field := strings.ToLower(strings.TrimSpace(kv[1]))
+		if header == "" || field == "" {
+			continue
+		}
+		mapping[header] = field
+	}
+	return mapping
+}
+
+// GetEvents returns recorded change events with seq > since, for clients
+// tailing the event log instead of polling the todo list.
+func (h *TodoHandler) GetEvents(c *gin.Context) error {
+	since, _ := strconv.ParseUint(c.Query("since"), 10, 64)
+
+	records, err := h.service.EventsSince(since)
+	if err != nil {
+		return err
+	}
+
+	c.JSON(http.StatusOK, gin.H{"events": records})
+	return nil
+}
@@ -14,7 +14,7 @@ import (
 )
 
 func TestSnoozeTodoInvalidJSON(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	created := svc.Create(models.TodoCreate{Text: "Test"})
 	
@@ -25,13 +25,13 @@ func TestSnoozeTodoInvalidJSON(t *testing.T) {
 		bytes.NewBufferString("{invalid}"))
 	c.Request.Header.Set("Content-Type", "application/json")
 	
-	handler.SnoozeTodo(c)
+	Wrap(handler.SnoozeTodo)(c)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
 func TestSnoozeTodoMissingUntilField(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	created := svc.Create(models.TodoCreate{Text: "Test"})
 	
@@ -42,13 +42,13 @@ func TestSnoozeTodoMissingUntilField(t *testing.T) {
 		bytes.NewBufferString("{}"))
 	c.Request.Header.Set("Content-Type", "application/json")
 	
-	handler.SnoozeTodo(c)
+	Wrap(handler.SnoozeTodo)(c)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
 func TestSnoozeAndUnsnoozeSequence(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	created := svc.Create(models.TodoCreate{Text: "Test"})
 
@@ -78,13 +78,13 @@ func TestSnoozeAndUnsnoozeSequence(t *testing.T) {
 }
 
 func TestGetUpcomingRemindersEmpty(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	svc.ClearCompleted()
 	
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	
-	handler.GetUpcomingReminders(c)
+	Wrap(handler.GetUpcomingReminders)(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	
@@ -94,7 +94,7 @@ func TestGetUpcomingRemindersEmpty(t *testing.T) {
 }
 
 func TestGetByTagNonExistent(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	svc.ClearCompleted()
 	
 	svc.Create(models.TodoCreate{
@@ -106,7 +106,7 @@ func TestGetByTagNonExistent(t *testing.T) {
 	c, _ := gin.CreateTestContext(w)
 	c.Params = gin.Params{{Key: "tag", Value: "nonexistent"}}
 	
-	handler.GetByTag(c)
+	Wrap(handler.GetByTag)(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	
@@ -116,14 +116,14 @@ func TestGetByTagNonExistent(t *testing.T) {
 }
 
 func TestGetByCategoryNonExistent(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	svc.ClearCompleted()
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	c.Params = gin.Params{{Key: "category", Value: "NonExistent"}}
 	
-	handler.GetByCategory(c)
+	Wrap(handler.GetByCategory)(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	
@@ -133,7 +133,7 @@ func TestGetByCategoryNonExistent(t *testing.T) {
 }
 
 func TestGetAllTagsEmpty(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	svc.ClearCompleted()
 	
 	svc.Create(models.TodoCreate{Text: "No Tags"})
@@ -141,7 +141,7 @@ func TestGetAllTagsEmpty(t *testing.T) {
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	
-	handler.GetAllTags(c)
+	Wrap(handler.GetAllTags)(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	
@@ -151,7 +151,7 @@ func TestGetAllTagsEmpty(t *testing.T) {
 }
 
 func TestBulkOperationInvalidOperation(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	todo := svc.Create(models.TodoCreate{Text: "Test"})
 
@@ -167,13 +167,13 @@ func TestBulkOperationInvalidOperation(t *testing.T) {
 	c.Request, _ = http.NewRequest("POST", "/api/todos/bulk", bytes.NewBuffer(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 	
-	handler.BulkOperation(c)
+	Wrap(handler.BulkOperation)(c)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
 func TestBulkOperationEmptyIDList(t *testing.T) {
-	handler, _ := setupTestHandler()
+	handler, _ := setupTestHandler(t)
 
 	input := models.BulkOperation{
 		IDs:       []string{},
@@ -187,13 +187,13 @@ func TestBulkOperationEmptyIDList(t *testing.T) {
 	c.Request, _ = http.NewRequest("POST", "/api/todos/bulk", bytes.NewBuffer(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 	
-	handler.BulkOperation(c)
+	Wrap(handler.BulkOperation)(c)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
 func TestBulkOperationUpdatePriorityMissingData(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	todo := svc.Create(models.TodoCreate{Text: "Test"})
 
@@ -210,13 +210,13 @@ func TestBulkOperationUpdatePriorityMissingData(t *testing.T) {
 	c.Request, _ = http.NewRequest("POST", "/api/todos/bulk", bytes.NewBuffer(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 	
-	handler.BulkOperation(c)
+	Wrap(handler.BulkOperation)(c)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
 func TestExportTodosInvalidFilter(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	svc.Create(models.TodoCreate{Text: "Test"})
 
@@ -231,7 +231,7 @@ func TestExportTodosInvalidFilter(t *testing.T) {
 }
 
 func TestExportTodosCheckHeaders(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	svc.Create(models.TodoCreate{Text: "Test"})
 
@@ -248,7 +248,7 @@ func TestExportTodosCheckHeaders(t *testing.T) {
 }
 
 func TestImportTodosMissingMode(t *testing.T) {
-	handler, _ := setupTestHandler()
+	handler, _ := setupTestHandler(t)
 
 	input := models.ImportRequest{
 		Todos: []models.TodoCreate{
@@ -264,14 +264,14 @@ func TestImportTodosMissingMode(t *testing.T) {
 	c.Request, _ = http.NewRequest("POST", "/api/import/todos", bytes.NewBuffer(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 	
-	handler.ImportTodos(c)
+	Wrap(handler.ImportTodos)(c)
 
 	// Should default to "append"
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
 func TestImportTodosInvalidMode(t *testing.T) {
-	handler, _ := setupTestHandler()
+	handler, _ := setupTestHandler(t)
 
 	input := models.ImportRequest{
 		Todos: []models.TodoCreate{{Text: "Test"}},
@@ -285,13 +285,13 @@ func TestImportTodosInvalidMode(t *testing.T) {
 	c.Request, _ = http.NewRequest("POST", "/api/import/todos", bytes.NewBuffer(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 	
-	handler.ImportTodos(c)
+	Wrap(handler.ImportTodos)(c)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
 func TestImportTodosMalformedJSON(t *testing.T) {
-	handler, _ := setupTestHandler()
+	handler, _ := setupTestHandler(t)
 	
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -299,13 +299,13 @@ func TestImportTodosMalformedJSON(t *testing.T) {
 		bytes.NewBufferString("{invalid json}"))
 	c.Request.Header.Set("Content-Type", "application/json")
 	
-	handler.ImportTodos(c)
+	Wrap(handler.ImportTodos)(c)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
 func TestImportExportRoundTrip(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	svc.ClearCompleted()
 	
 	// Create test todos
@@ -365,3 +365,62 @@ func TestImportExportRoundTrip(t *testing.T) {
 }
 
 
+
+func TestGetTodoOccurrencesReturnsWindow(t *testing.T) {
+	handler, svc := setupTestHandler(t)
+
+	dueDate := "2026-01-05" // a Monday
+	created := svc.Create(models.TodoCreate{
+		Text:       "Standup",
+		Recurrence: "FREQ=WEEKLY;BYDAY=MO,WE",
+		DueDate:    &dueDate,
+	})
+
+	from, _ := time.Parse("2006-01-02", dueDate)
+	to := from.AddDate(0, 0, 10)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: created.ID}}
+	c.Request, _ = http.NewRequest("GET", "/api/todos/"+created.ID+"/occurrences?from="+
+		from.Format(time.RFC3339)+"&to="+to.Format(time.RFC3339), nil)
+
+	Wrap(handler.GetTodoOccurrences)(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var body struct {
+		Occurrences []time.Time `json:"occurrences"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &body)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(body.Occurrences))
+}
+
+func TestGetTodoOccurrencesNotFound(t *testing.T) {
+	handler, _ := setupTestHandler(t)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "nonexistent"}}
+	c.Request, _ = http.NewRequest("GET", "/api/todos/nonexistent/occurrences", nil)
+
+	Wrap(handler.GetTodoOccurrences)(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetTodoOccurrencesInvalidFrom(t *testing.T) {
+	handler, svc := setupTestHandler(t)
+
+	created := svc.Create(models.TodoCreate{Text: "Standup", Recurrence: "FREQ=DAILY"})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: created.ID}}
+	c.Request, _ = http.NewRequest("GET", "/api/todos/"+created.ID+"/occurrences?from=not-a-time", nil)
+
+	Wrap(handler.GetTodoOccurrences)(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -15,7 +15,7 @@ import (
 )
 
 func TestSnoozeTodo(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	created := svc.Create(models.TodoCreate{Text: "Test Todo"})
 	
@@ -29,7 +29,7 @@ func TestSnoozeTodo(t *testing.T) {
 	c.Request, _ = http.NewRequest("PATCH", "/api/todos/"+created.ID+"/snooze", bytes.NewBuffer(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 	
-	handler.SnoozeTodo(c)
+	Wrap(handler.SnoozeTodo)(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	
@@ -40,7 +40,7 @@ func TestSnoozeTodo(t *testing.T) {
 }
 
 func TestUnsnoozeTodo(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	created := svc.Create(models.TodoCreate{Text: "Test Todo"})
 	until := time.Now().Add(2 * time.Hour)
@@ -50,7 +50,7 @@ func TestUnsnoozeTodo(t *testing.T) {
 	c, _ := gin.CreateTestContext(w)
 	c.Params = gin.Params{{Key: "id", Value: created.ID}}
 	
-	handler.UnsnoozeTodo(c)
+	Wrap(handler.UnsnoozeTodo)(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	
@@ -60,22 +60,56 @@ func TestUnsnoozeTodo(t *testing.T) {
 	assert.Nil(t, todo.SnoozedUntil)
 }
 
+func TestSetAndClearTodoRecurrence(t *testing.T) {
+	handler, svc := setupTestHandler(t)
+
+	created := svc.Create(models.TodoCreate{Text: "Test Todo"})
+
+	input := models.RecurrenceRequest{RecurrenceRule: models.RecurrenceWeekly}
+	body, _ := json.Marshal(input)
+
+	w1 := httptest.NewRecorder()
+	c1, _ := gin.CreateTestContext(w1)
+	c1.Params = gin.Params{{Key: "id", Value: created.ID}}
+	c1.Request, _ = http.NewRequest("POST", "/api/todos/"+created.ID+"/recurrence", bytes.NewBuffer(body))
+	c1.Request.Header.Set("Content-Type", "application/json")
+
+	Wrap(handler.SetTodoRecurrence)(c1)
+
+	assert.Equal(t, http.StatusOK, w1.Code)
+
+	var todo models.Todo
+	assert.NoError(t, json.Unmarshal(w1.Body.Bytes(), &todo))
+	assert.Equal(t, models.RecurrenceWeekly, todo.RecurrenceRule)
+
+	w2 := httptest.NewRecorder()
+	c2, _ := gin.CreateTestContext(w2)
+	c2.Params = gin.Params{{Key: "id", Value: created.ID}}
+
+	Wrap(handler.ClearTodoRecurrence)(c2)
+
+	assert.Equal(t, http.StatusOK, w2.Code)
+
+	var cleared models.Todo
+	assert.NoError(t, json.Unmarshal(w2.Body.Bytes(), &cleared))
+	assert.Equal(t, models.RecurrenceNone, cleared.RecurrenceRule)
+}
+
 func TestGetUpcomingReminders(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	tomorrow := time.Now().Add(12 * time.Hour).Format("2006-01-02")
-	reminderTime := "10:00"
-	
+
 	svc.Create(models.TodoCreate{
-		Text:         "Has Reminder",
-		DueDate:      &tomorrow,
-		ReminderTime: &reminderTime,
+		Text:      "Has Reminder",
+		DueDate:   &tomorrow,
+		Reminders: []models.Reminder{{RelativeTo: models.ReminderAnchorDueDate, OffsetSeconds: 10 * 3600}},
 	})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	
-	handler.GetUpcomingReminders(c)
+	Wrap(handler.GetUpcomingReminders)(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	
@@ -85,7 +119,7 @@ func TestGetUpcomingReminders(t *testing.T) {
 }
 
 func TestGetByTag(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	svc.Create(models.TodoCreate{
 		Text: "Work Todo",
@@ -96,7 +130,7 @@ func TestGetByTag(t *testing.T) {
 	c, _ := gin.CreateTestContext(w)
 	c.Params = gin.Params{{Key: "tag", Value: "work"}}
 	
-	handler.GetByTag(c)
+	Wrap(handler.GetByTag)(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	
@@ -107,7 +141,7 @@ func TestGetByTag(t *testing.T) {
 }
 
 func TestGetAllTags(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	svc.Create(models.TodoCreate{
 		Text: "Todo 1",
@@ -117,7 +151,7 @@ func TestGetAllTags(t *testing.T) {
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	
-	handler.GetAllTags(c)
+	Wrap(handler.GetAllTags)(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	
@@ -128,7 +162,7 @@ func TestGetAllTags(t *testing.T) {
 }
 
 func TestBulkOperation(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	todo1 := svc.Create(models.TodoCreate{Text: "Todo 1"})
 	todo2 := svc.Create(models.TodoCreate{Text: "Todo 2"})
@@ -145,7 +179,7 @@ func TestBulkOperation(t *testing.T) {
 	c.Request, _ = http.NewRequest("POST", "/api/todos/bulk", bytes.NewBuffer(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 	
-	handler.BulkOperation(c)
+	Wrap(handler.BulkOperation)(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	
@@ -156,7 +190,7 @@ func TestBulkOperation(t *testing.T) {
 }
 
 func TestBulkDelete(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	todo1 := svc.Create(models.TodoCreate{Text: "Todo 1"})
 	todo2 := svc.Create(models.TodoCreate{Text: "Todo 2"})
@@ -173,7 +207,7 @@ func TestBulkDelete(t *testing.T) {
 	c.Request, _ = http.NewRequest("POST", "/api/todos/bulk", bytes.NewBuffer(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 	
-	handler.BulkOperation(c)
+	Wrap(handler.BulkOperation)(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	
@@ -185,7 +219,7 @@ func TestBulkDelete(t *testing.T) {
 }
 
 func TestExportTodos(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	svc.Create(models.TodoCreate{Text: "Todo 1"})
 	svc.Create(models.TodoCreate{Text: "Todo 2"})
@@ -208,8 +242,175 @@ func TestExportTodos(t *testing.T) {
 	assert.Equal(t, 2, result.Count)
 }
 
+func TestExportTodosCSV(t *testing.T) {
+	handler, svc := setupTestHandler(t)
+
+	svc.Create(models.TodoCreate{Text: "Todo 1"})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/api/export/todos?format=csv", nil)
+
+	handler.ExportTodos(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/csv")
+	assert.Contains(t, w.Header().Get("Content-Disposition"), ".csv")
+	assert.Contains(t, w.Body.String(), "Todo 1")
+}
+
+func TestSearchTodos(t *testing.T) {
+	handler, svc := setupTestHandler(t)
+
+	svc.Create(models.TodoCreate{Text: "Buy milk", Priority: models.PriorityHigh})
+	svc.Create(models.TodoCreate{Text: "Walk the dog"})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/api/todos/search?q=milk", nil)
+
+	Wrap(handler.SearchTodos)(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var todos []models.Todo
+	err := json.Unmarshal(w.Body.Bytes(), &todos)
+	assert.NoError(t, err)
+	assert.Len(t, todos, 1)
+	assert.Equal(t, "Buy milk", todos[0].Text)
+}
+
+func TestSearchTodosRequiresQuery(t *testing.T) {
+	handler, _ := setupTestHandler(t)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/api/todos/search", nil)
+
+	Wrap(handler.SearchTodos)(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestExportTodosNDJSON(t *testing.T) {
+	handler, svc := setupTestHandler(t)
+
+	svc.Create(models.TodoCreate{Text: "Todo 1"})
+	svc.Create(models.TodoCreate{Text: "Todo 2"})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/api/export/todos?format=ndjson", nil)
+
+	handler.ExportTodos(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/x-ndjson")
+	assert.Contains(t, w.Header().Get("Content-Disposition"), ".ndjson")
+
+	lines := bytes.Split(bytes.TrimSpace(w.Body.Bytes()), []byte("\n"))
+	assert.Len(t, lines, 2)
+}
+
+func TestExportTodosMarkdown(t *testing.T) {
+	handler, svc := setupTestHandler(t)
+
+	svc.Create(models.TodoCreate{Text: "Todo 1"})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/api/export/todos?format=md", nil)
+
+	handler.ExportTodos(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/markdown")
+	assert.Contains(t, w.Body.String(), "- [ ]")
+}
+
+func TestImportTodosCSV(t *testing.T) {
+	handler, _ := setupTestHandler(t)
+
+	csvBody := "ID,Text,Priority,Completed,DueDate,StartDate,Reminders,Tags,Category,CreatedAt,UpdatedAt\n" +
+		",Imported from CSV,high,false,,,,work;urgent,,,\n"
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/api/import/todos?format=csv", bytes.NewBufferString(csvBody))
+	c.Request.Header.Set("Content-Type", "text/csv")
+
+	Wrap(handler.ImportTodos)(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var result models.ImportResult
+	err := json.Unmarshal(w.Body.Bytes(), &result)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Imported)
+}
+
+func TestImportTodosCSVWithColumnMapping(t *testing.T) {
+	handler, _ := setupTestHandler(t)
+
+	csvBody := "note,labels\nImported via mapping,work|urgent\n"
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/api/import/todos?format=csv&columns=note:text,labels:tags&tagSep=|", bytes.NewBufferString(csvBody))
+	c.Request.Header.Set("Content-Type", "text/csv")
+
+	Wrap(handler.ImportTodos)(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var result models.ImportResult
+	err := json.Unmarshal(w.Body.Bytes(), &result)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Imported)
+}
+
+func TestImportTodosNDJSON(t *testing.T) {
+	handler, _ := setupTestHandler(t)
+
+	ndjsonBody := `{"text":"Imported 1","priority":"high"}` + "\n" + `{"text":"Imported 2"}` + "\n"
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/api/import/todos?format=ndjson", bytes.NewBufferString(ndjsonBody))
+	c.Request.Header.Set("Content-Type", "application/x-ndjson")
+
+	Wrap(handler.ImportTodos)(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var result models.ImportResult
+	err := json.Unmarshal(w.Body.Bytes(), &result)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Imported)
+}
+
+func TestImportTodosMarkdown(t *testing.T) {
+	handler, _ := setupTestHandler(t)
+
+	mdBody := "## Work\n- [ ] 🔴 Imported from Markdown (2026-01-02)\n"
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/api/import/todos?format=md", bytes.NewBufferString(mdBody))
+	c.Request.Header.Set("Content-Type", "text/markdown")
+
+	Wrap(handler.ImportTodos)(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var result models.ImportResult
+	err := json.Unmarshal(w.Body.Bytes(), &result)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Imported)
+}
+
 func TestImportTodos(t *testing.T) {
-	handler, _ := setupTestHandler()
+	handler, _ := setupTestHandler(t)
 
 	input := models.ImportRequest{
 		Todos: []models.TodoCreate{
@@ -226,7 +427,7 @@ func TestImportTodos(t *testing.T) {
 	c.Request, _ = http.NewRequest("POST", "/api/import/todos", bytes.NewBuffer(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 	
-	handler.ImportTodos(c)
+	Wrap(handler.ImportTodos)(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	
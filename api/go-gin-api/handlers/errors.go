@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors a ReturnHandler can return (wrapped with fmt.Errorf's
+// %w, or returned bare) to tell Wrap which HTTP status to respond with.
+// An error that doesn't wrap one of these becomes a 500.
+var (
+	ErrNotFound    = errors.New("not found")
+	ErrValidation  = errors.New("validation failed")
+	ErrConflict    = errors.New("conflict")
+	ErrBulkPartial = errors.New("bulk operation partially completed")
+)
+
+// statuser is implemented by errors that need a status other than the one
+// statusForError would derive from the sentinels below (e.g. bulk
+// cancellation's 499/504 split). statusForError checks for it first.
+type statuser interface {
+	Status() int
+}
+
+// statusForError maps an error to the status Wrap should respond with: an
+// explicit Status() if the error provides one, else whichever sentinel
+// above it wraps. Unrecognized errors (and nil, which callers shouldn't
+// reach this with) default to 500.
+func statusForError(err error) int {
+	var se statuser
+	if errors.As(err, &se) {
+		return se.Status()
+	}
+
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrValidation):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, ErrBulkPartial):
+		return http.StatusMultiStatus
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// visibleError marks its wrapped error as safe to show to the client
+// verbatim. Wrap shows the message of any error marked with Visible, and
+// substitutes a generic "internal error" for everything else (logging the
+// real message instead), so a handler can't accidentally leak an internal
+// failure's details just by returning it.
+type visibleError struct {
+	err error
+}
+
+func (v *visibleError) Error() string { return v.err.Error() }
+func (v *visibleError) Unwrap() error { return v.err }
+
+// Visible marks err so Wrap sends its message to the client instead of a
+// generic "internal error". Use it for errors a handler constructs itself
+// (validation messages, "todo not found", ...); leave lower-level failures
+// (store/event-log errors) unmarked so their detail only reaches the log.
+func Visible(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &visibleError{err: err}
+}
+
+// isVisible reports whether err (or anything it wraps) was marked with
+// Visible.
+func isVisible(err error) bool {
+	var v *visibleError
+	return errors.As(err, &v)
+}
+
+// notFound, validationError, and conflictError build a handler-facing error
+// that's both typed (so Wrap picks the right status) and Visible (so the
+// given message, not "internal error", reaches the client).
+func notFound(message string) error {
+	return Visible(fmt.Errorf("%s: %w", message, ErrNotFound))
+}
+
+func validationError(message string) error {
+	return Visible(fmt.Errorf("%s: %w", message, ErrValidation))
+}
+
+func conflictError(message string) error {
+	return Visible(fmt.Errorf("%s: %w", message, ErrConflict))
+}
+
+// bulkCancelledError reports a bulk operation (BulkOperation or
+// ImportTodos) that stopped partway through: 499 (nginx's client-closed-
+// request convention) if the client disconnected, 504 if the request
+// deadline middleware cut it off first.
+type bulkCancelledError struct {
+	clientDisconnected bool
+	affected           int
+}
+
+func (e *bulkCancelledError) Error() string {
+	return "bulk operation cancelled before completion"
+}
+
+func (e *bulkCancelledError) Unwrap() error { return ErrBulkPartial }
+
+func (e *bulkCancelledError) Status() int {
+	if e.clientDisconnected {
+		return 499
+	}
+	return http.StatusGatewayTimeout
+}
+
+func (e *bulkCancelledError) Details() interface{} {
+	return map[string]interface{}{"affected": e.affected}
+}
+
+// bulkCancelled builds the error BulkOperation/ImportTodos return when ctx
+// was cancelled partway through: clientDisconnected distinguishes the
+// client hanging up from this API's own request-deadline middleware firing
+// first, which Status() turns into 499 vs 504.
+func bulkCancelled(ctx context.Context, affected int) error {
+	return Visible(&bulkCancelledError{
+		clientDisconnected: ctx.Err() == context.Canceled,
+		affected:           affected,
+	})
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetJobs lists every reminder/recurrence job still queued, soonest-first,
+// for observability into the scheduler's job queue.
+func (h *TodoHandler) GetJobs(c *gin.Context) error {
+	jobs, err := h.service.PendingJobs()
+	if err != nil {
+		return err
+	}
+
+	c.JSON(http.StatusOK, gin.H{"jobs": jobs})
+	return nil
+}
+
+// DeleteJob cancels a pending job by ID, e.g. to suppress a reminder whose
+// todo was deleted out from under it.
+func (h *TodoHandler) DeleteJob(c *gin.Context) error {
+	id := c.Param("id")
+
+	if err := h.service.CancelJob(id); err != nil {
+		return err
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Job cancelled"})
+	return nil
+}
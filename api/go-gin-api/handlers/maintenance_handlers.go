@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+	"spicytodo-go-api/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetMaintenanceWindows lists every planned maintenance window.
+func (h *TodoHandler) GetMaintenanceWindows(c *gin.Context) error {
+	c.JSON(http.StatusOK, h.service.ListMaintenanceWindows())
+	return nil
+}
+
+// GetActiveMaintenanceWindows lists the maintenance windows firing right now.
+func (h *TodoHandler) GetActiveMaintenanceWindows(c *gin.Context) error {
+	c.JSON(http.StatusOK, h.service.ActiveMaintenanceWindows())
+	return nil
+}
+
+// CreateMaintenanceWindow adds a new maintenance window, during which
+// reminders for its targeted todos are suppressed and their recurrence
+// materialization is deferred.
+func (h *TodoHandler) CreateMaintenanceWindow(c *gin.Context) error {
+	var input models.MaintenanceWindowCreate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		return validationError(err.Error())
+	}
+
+	window := h.service.CreateMaintenanceWindow(input)
+	c.JSON(http.StatusCreated, window)
+	return nil
+}
+
+// UpdateMaintenanceWindow applies partial changes to an existing window.
+func (h *TodoHandler) UpdateMaintenanceWindow(c *gin.Context) error {
+	id := c.Param("id")
+
+	var input models.MaintenanceWindowUpdate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		return validationError(err.Error())
+	}
+
+	window, exists := h.service.UpdateMaintenanceWindow(id, input)
+	if !exists {
+		return notFound("Maintenance window not found")
+	}
+
+	c.JSON(http.StatusOK, window)
+	return nil
+}
+
+// DeleteMaintenanceWindow removes a maintenance window by ID.
+func (h *TodoHandler) DeleteMaintenanceWindow(c *gin.Context) error {
+	id := c.Param("id")
+
+	if !h.service.DeleteMaintenanceWindow(id) {
+		return notFound("Maintenance window not found")
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Maintenance window deleted"})
+	return nil
+}
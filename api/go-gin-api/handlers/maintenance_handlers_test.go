@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"spicytodo-go-api/models"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetActiveMaintenanceWindows(t *testing.T) {
+	handler, svc := setupTestHandler(t)
+
+	now := time.Now()
+	svc.CreateMaintenanceWindow(models.MaintenanceWindowCreate{
+		Name:   "Active now",
+		Target: models.MaintenanceTarget{All: true},
+		Schedule: models.MaintenanceSchedule{
+			Start: now.Add(-time.Hour),
+			End:   &[]time.Time{now.Add(time.Hour)}[0],
+		},
+	})
+	svc.CreateMaintenanceWindow(models.MaintenanceWindowCreate{
+		Name:   "Already closed",
+		Target: models.MaintenanceTarget{All: true},
+		Schedule: models.MaintenanceSchedule{
+			Start: now.Add(-3 * time.Hour),
+			End:   &[]time.Time{now.Add(-time.Hour)}[0],
+		},
+	})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/api/maintenance/active", nil)
+
+	Wrap(handler.GetActiveMaintenanceWindows)(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var windows []models.MaintenanceWindow
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &windows))
+	assert.Equal(t, 1, len(windows))
+	assert.Equal(t, "Active now", windows[0].Name)
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"errors"
+	"expvar"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReturnHandler is a gin handler that reports failure by returning an error
+// instead of writing a response itself. Wrap adapts one into a
+// gin.HandlerFunc: a nil error means the handler already wrote its own
+// response; a non-nil error is turned into a JSON error body and recorded
+// in the /debug/vars metrics.
+type ReturnHandler func(*gin.Context) error
+
+// errorBody is the JSON shape Wrap writes for a non-nil error.
+type errorBody struct {
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+// detailer is implemented by errors that carry structured details (e.g. a
+// partial bulk operation's affected count) for errorBody.Details.
+type detailer interface {
+	Details() interface{}
+}
+
+// Wrap adapts a ReturnHandler into a gin.HandlerFunc: it writes the JSON
+// error response for a returned error (mapping it to a status via
+// statusForError and showing its message only if it's Visible), and records
+// this request's duration, status, and response size into the /debug/vars
+// metrics regardless of whether the handler itself returned an error.
+func Wrap(h ReturnHandler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		err := h(c)
+		status := c.Writer.Status()
+
+		if err != nil {
+			status = statusForError(err)
+			body := errorBody{Code: http.StatusText(status), Message: "internal error"}
+			if isVisible(err) {
+				body.Message = err.Error()
+			} else {
+				log.Printf("handlers: %s %s: %v", c.Request.Method, c.FullPath(), err)
+			}
+			var d detailer
+			if errors.As(err, &d) {
+				body.Details = d.Details()
+			}
+			c.JSON(status, body)
+		}
+
+		recordRequest(routeLabel(c), status, time.Since(start), c.Writer.Size())
+	}
+}
+
+// routeLabel builds the "METHOD path" metrics key, falling back to the raw
+// request path when gin has no matched route template (e.g. a 404).
+func routeLabel(c *gin.Context) string {
+	if c.Request == nil {
+		return c.FullPath()
+	}
+	if path := c.FullPath(); path != "" {
+		return c.Request.Method + " " + path
+	}
+	return c.Request.Method + " " + c.Request.URL.Path
+}
+
+// routeStats accumulates /debug/vars metrics for a single "METHOD path" key.
+type routeStats struct {
+	Count      int64 `json:"count"`
+	ErrorCount int64 `json:"errorCount"`
+	TotalNanos int64 `json:"totalNanos"`
+	Bytes      int64 `json:"bytes"`
+}
+
+var (
+	metricsMu      sync.Mutex
+	metricsByRoute = map[string]*routeStats{}
+)
+
+func init() {
+	expvar.Publish("http_requests", expvar.Func(func() interface{} {
+		metricsMu.Lock()
+		defer metricsMu.Unlock()
+
+		snapshot := make(map[string]routeStats, len(metricsByRoute))
+		for route, stats := range metricsByRoute {
+			snapshot[route] = *stats
+		}
+		return snapshot
+	}))
+}
+
+func recordRequest(route string, status int, duration time.Duration, bytes int) {
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+
+	stats, ok := metricsByRoute[route]
+	if !ok {
+		stats = &routeStats{}
+		metricsByRoute[route] = stats
+	}
+
+	stats.Count++
+	if status >= http.StatusBadRequest {
+		stats.ErrorCount++
+	}
+	stats.TotalNanos += duration.Nanoseconds()
+	stats.Bytes += int64(bytes)
+}
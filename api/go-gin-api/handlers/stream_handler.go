@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"spicytodo-go-api/service"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const streamHeartbeatInterval = 15 * time.Second
+
+// StreamTodos upgrades the connection to Server-Sent Events and pushes a
+// JSON-encoded ChangeEvent whenever a mutating handler changes todo state.
+// Clients may narrow the stream with `?tag=`, `?category=`, `?priority=`,
+// `?filter=active` or `?filter=completed`, or the equivalent
+// `?topic=tag:work`, `?topic=priority:high`, `?topic=id:<uuid>` form
+// (repeatable; each topic narrows the filter further). Clients behind
+// proxies that don't like long-lived connections can pass `?poll=1&since=<seq>`
+// instead, which returns the buffered events
+// since that sequence as a single JSON array and closes. Either mode can
+// resume after a disconnect via the `Last-Event-ID` header or `since`
+// query param, paired with the event store's sequence numbers.
+func (h *TodoHandler) StreamTodos(c *gin.Context) {
+	h.stream(c, parseStreamFilter(c))
+}
+
+// StreamTopic is sugar over StreamTodos for clients that want a single tag's
+// events without building a query string: GET /api/topics/:tag behaves the
+// same as GET /api/todos/stream?tag=:tag, including the `poll=1` fallback.
+func (h *TodoHandler) StreamTopic(c *gin.Context) {
+	filter := parseStreamFilter(c)
+	filter.Tag = c.Param("tag")
+	h.stream(c, filter)
+}
+
+// StreamReminders is sugar over StreamTodos scoped to only "ReminderDue"
+// events, for clients that just want reminder delivery and don't care
+// about the rest of a todo's lifecycle. Accepts the same `?tag=`,
+// `?category=`, `?priority=`, `?poll=1`, and `?since=` params as
+// GET /api/todos/stream.
+func (h *TodoHandler) StreamReminders(c *gin.Context) {
+	filter := parseStreamFilter(c)
+	filter.EventType = "ReminderDue"
+	h.stream(c, filter)
+}
+
+// reminderActivityEventTypes are the event types GET
+// /api/todos/reminders/stream narrows to: a reminder firing, a snooze or
+// unsnooze, and a completion toggle.
+var reminderActivityEventTypes = []string{"ReminderDue", "TodoSnoozed", "TodoCompleted"}
+
+// StreamReminderActivity is sugar over StreamTodos scoped to reminder-firing,
+// snooze/unsnooze, and completion events, for clients building a reminders
+// inbox that don't want every todo edit. Accepts the same `?tag=`,
+// `?category=`, `?priority=`, `?poll=1`, and `?since=` params as
+// GET /api/todos/stream.
+func (h *TodoHandler) StreamReminderActivity(c *gin.Context) {
+	filter := parseStreamFilter(c)
+	filter.EventTypes = reminderActivityEventTypes
+	h.stream(c, filter)
+}
+
+func (h *TodoHandler) stream(c *gin.Context, filter service.StreamFilter) {
+	if c.Query("poll") == "1" {
+		since, _ := strconv.ParseUint(c.Query("since"), 10, 64)
+		records, err := h.service.EventsSince(since)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read event log"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"events": records})
+		return
+	}
+
+	id, events := h.service.Subscribe(filter)
+	defer h.service.Unsubscribe(id)
+
+	resumeFrom := c.GetHeader("Last-Event-ID")
+	if resumeFrom == "" {
+		resumeFrom = c.Query("since")
+	}
+	if resumeFrom != "" {
+		if since, err := strconv.ParseUint(resumeFrom, 10, 64); err == nil {
+			if backlog, err := h.service.EventsSince(since); err == nil {
+				for _, record := range backlog {
+					writeSSERecord(c, record.Seq, record.Type, record.Data)
+				}
+			}
+		}
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			writeSSEEvent(c, event)
+			return true
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
+
+// parseStreamFilter builds a StreamFilter from the discrete `?tag=`,
+// `?category=`, `?priority=` params and/or repeated `?topic=key:value`
+// params (key one of tag, category, priority, id). Later params win over
+// earlier ones for the same field.
+func parseStreamFilter(c *gin.Context) service.StreamFilter {
+	filter := service.StreamFilter{
+		Tag:      c.Query("tag"),
+		Category: c.Query("category"),
+		Priority: c.Query("priority"),
+		Status:   c.Query("filter"),
+	}
+
+	for _, topic := range c.QueryArray("topic") {
+		key, value, ok := strings.Cut(topic, ":")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "tag":
+			filter.Tag = value
+		case "category":
+			filter.Category = value
+		case "priority":
+			filter.Priority = value
+		case "id":
+			filter.ID = value
+		}
+	}
+
+	return filter
+}
+
+func writeSSEEvent(c *gin.Context, event service.ChangeEvent) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", event.Seq, event.Type, payload)
+	c.Writer.Flush()
+}
+
+func writeSSERecord(c *gin.Context, seq uint64, eventType string, data []byte) {
+	fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", seq, eventType, data)
+	c.Writer.Flush()
+}
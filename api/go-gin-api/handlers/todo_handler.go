@@ -34,84 +34,112 @@ func (h *TodoHandler) GetHealth(c *gin.Context) {
 	})
 }
 
-func (h *TodoHandler) GetTodos(c *gin.Context) {
+func (h *TodoHandler) GetTodos(c *gin.Context) error {
 	filter := c.Query("filter")
 	search := c.Query("search")
 	priority := c.Query("priority")
 
 	todos := h.service.GetAll(filter, search, priority)
 	c.JSON(http.StatusOK, todos)
+	return nil
 }
 
-func (h *TodoHandler) CreateTodo(c *gin.Context) {
+func (h *TodoHandler) CreateTodo(c *gin.Context) error {
 	var input models.TodoCreate
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
+		return validationError(err.Error())
+	}
+	if err := service.ValidateReminders(input.Reminders, input.DueDate, input.StartDate); err != nil {
+		return validationError(err.Error())
 	}
 
 	todo := h.service.Create(input)
 	c.JSON(http.StatusCreated, todo)
+	return nil
 }
 
-func (h *TodoHandler) GetTodoByID(c *gin.Context) {
+func (h *TodoHandler) GetTodoByID(c *gin.Context) error {
 	id := c.Param("id")
 	todo, exists := h.service.GetByID(id)
 	if !exists {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Todo not found"})
-		return
+		return notFound("Todo not found")
 	}
 	c.JSON(http.StatusOK, todo)
+	return nil
 }
 
-func (h *TodoHandler) UpdateTodo(c *gin.Context) {
+func (h *TodoHandler) UpdateTodo(c *gin.Context) error {
 	id := c.Param("id")
 
 	var input models.TodoUpdate
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
+		return validationError(err.Error())
+	}
+
+	if input.Reminders != nil {
+		existing, exists := h.service.GetByID(id)
+		if !exists {
+			return notFound("Todo not found")
+		}
+		dueDate, startDate := existing.DueDate, existing.StartDate
+		if input.DueDate != nil {
+			dueDate = input.DueDate
+		}
+		if input.StartDate != nil {
+			startDate = input.StartDate
+		}
+		if err := service.ValidateReminders(input.Reminders, dueDate, startDate); err != nil {
+			return validationError(err.Error())
+		}
 	}
 
 	todo, exists := h.service.Update(id, input)
 	if !exists {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Todo not found"})
-		return
+		return notFound("Todo not found")
 	}
 
 	c.JSON(http.StatusOK, todo)
+	return nil
 }
 
-func (h *TodoHandler) DeleteTodo(c *gin.Context) {
+func (h *TodoHandler) DeleteTodo(c *gin.Context) error {
 	id := c.Param("id")
 
 	if !h.service.Delete(id) {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Todo not found"})
-		return
+		return notFound("Todo not found")
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Todo deleted successfully"})
+	return nil
 }
 
-func (h *TodoHandler) ToggleTodo(c *gin.Context) {
+func (h *TodoHandler) ToggleTodo(c *gin.Context) error {
 	id := c.Param("id")
 
 	todo, exists := h.service.Toggle(id)
 	if !exists {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Todo not found"})
-		return
+		return notFound("Todo not found")
 	}
 
 	c.JSON(http.StatusOK, todo)
+	return nil
 }
 
-func (h *TodoHandler) GetStats(c *gin.Context) {
-	stats := h.service.GetStats()
+// GetStats returns the todo summary. `exclude_maintenance=true` leaves
+// todos inside an active maintenance window out of OverdueCount.
+func (h *TodoHandler) GetStats(c *gin.Context) error {
+	var stats models.TodoStats
+	if c.Query("exclude_maintenance") == "true" {
+		stats = h.service.GetStatsExcludingMaintenance()
+	} else {
+		stats = h.service.GetStats()
+	}
 	c.JSON(http.StatusOK, stats)
+	return nil
 }
 
-func (h *TodoHandler) ClearCompleted(c *gin.Context) {
+func (h *TodoHandler) ClearCompleted(c *gin.Context) error {
 	h.service.ClearCompleted()
 	c.JSON(http.StatusOK, gin.H{"message": "Completed todos cleared"})
+	return nil
 }
-
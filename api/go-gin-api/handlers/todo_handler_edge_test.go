@@ -13,10 +13,9 @@ import (
 )
 
 func TestCreateTodoWithAllFields(t *testing.T) {
-	handler, _ := setupTestHandler()
+	handler, _ := setupTestHandler(t)
 
 	dueDate := "2024-12-31"
-	reminderTime := "10:00"
 	category := "Work"
 
 	input := models.TodoCreate{
@@ -24,7 +23,7 @@ func TestCreateTodoWithAllFields(t *testing.T) {
 		Priority:       models.PriorityHigh,
 		Completed:      false,
 		DueDate:        &dueDate,
-		ReminderTime:   &reminderTime,
+		Reminders:      []models.Reminder{{RelativeTo: models.ReminderAnchorDueDate, OffsetSeconds: 10 * 3600}},
 		RecurrenceRule: models.RecurrenceWeekly,
 		Tags:           []string{"work", "urgent"},
 		Category:       &category,
@@ -37,7 +36,7 @@ func TestCreateTodoWithAllFields(t *testing.T) {
 	c.Request, _ = http.NewRequest("POST", "/api/todos", bytes.NewBuffer(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 	
-	handler.CreateTodo(c)
+	Wrap(handler.CreateTodo)(c)
 
 	assert.Equal(t, http.StatusCreated, w.Code)
 	
@@ -50,7 +49,7 @@ func TestCreateTodoWithAllFields(t *testing.T) {
 }
 
 func TestUpdateTodoPartialUpdate(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	created := svc.Create(models.TodoCreate{
 		Text:     "Original",
@@ -72,7 +71,7 @@ func TestUpdateTodoPartialUpdate(t *testing.T) {
 	c.Request, _ = http.NewRequest("PUT", "/api/todos/"+created.ID, bytes.NewBuffer(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 	
-	handler.UpdateTodo(c)
+	Wrap(handler.UpdateTodo)(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	
@@ -86,7 +85,7 @@ func TestUpdateTodoPartialUpdate(t *testing.T) {
 }
 
 func TestGetTodosWithAllQueryParams(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	svc.Create(models.TodoCreate{
 		Text:      "High Active Work",
@@ -108,7 +107,7 @@ func TestGetTodosWithAllQueryParams(t *testing.T) {
 	c, _ := gin.CreateTestContext(w)
 	c.Request, _ = http.NewRequest("GET", "/api/todos?filter=active&priority=high&search=Work", nil)
 	
-	handler.GetTodos(c)
+	Wrap(handler.GetTodos)(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	
@@ -121,20 +120,20 @@ func TestGetTodosWithAllQueryParams(t *testing.T) {
 }
 
 func TestCreateTodoMalformedJSON(t *testing.T) {
-	handler, _ := setupTestHandler()
+	handler, _ := setupTestHandler(t)
 	
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	c.Request, _ = http.NewRequest("POST", "/api/todos", bytes.NewBufferString("{invalid json}"))
 	c.Request.Header.Set("Content-Type", "application/json")
 	
-	handler.CreateTodo(c)
+	Wrap(handler.CreateTodo)(c)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
 func TestUpdateTodoMalformedJSON(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	created := svc.Create(models.TodoCreate{Text: "Test"})
 	
@@ -144,13 +143,13 @@ func TestUpdateTodoMalformedJSON(t *testing.T) {
 	c.Request, _ = http.NewRequest("PUT", "/api/todos/"+created.ID, bytes.NewBufferString("{invalid}"))
 	c.Request.Header.Set("Content-Type", "application/json")
 	
-	handler.UpdateTodo(c)
+	Wrap(handler.UpdateTodo)(c)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
 func TestToggleTodoMultipleTimes(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	created := svc.Create(models.TodoCreate{Text: "Test", Completed: false})
 
@@ -160,7 +159,7 @@ func TestToggleTodoMultipleTimes(t *testing.T) {
 		c, _ := gin.CreateTestContext(w)
 		c.Params = gin.Params{{Key: "id", Value: created.ID}}
 		
-		handler.ToggleTodo(c)
+		Wrap(handler.ToggleTodo)(c)
 		
 		assert.Equal(t, http.StatusOK, w.Code)
 	}
@@ -171,7 +170,7 @@ func TestToggleTodoMultipleTimes(t *testing.T) {
 }
 
 func TestGetStatsWithAllStatusCombinations(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	// Create todos with various combinations
 	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
@@ -212,7 +211,7 @@ func TestGetStatsWithAllStatusCombinations(t *testing.T) {
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	
-	handler.GetStats(c)
+	Wrap(handler.GetStats)(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	
@@ -228,7 +227,7 @@ func TestGetStatsWithAllStatusCombinations(t *testing.T) {
 }
 
 func TestClearCompletedMultipleTimes(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	svc.Create(models.TodoCreate{Text: "Active", Completed: false})
 	svc.Create(models.TodoCreate{Text: "Done", Completed: true})
@@ -250,7 +249,7 @@ func TestClearCompletedMultipleTimes(t *testing.T) {
 }
 
 func TestHealthCheckReturnsCorrectStructure(t *testing.T) {
-	handler, _ := setupTestHandler()
+	handler, _ := setupTestHandler(t)
 	
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -268,7 +267,7 @@ func TestHealthCheckReturnsCorrectStructure(t *testing.T) {
 }
 
 func TestRootEndpointStructure(t *testing.T) {
-	handler, _ := setupTestHandler()
+	handler, _ := setupTestHandler(t)
 	
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -286,7 +285,7 @@ func TestRootEndpointStructure(t *testing.T) {
 }
 
 func TestCreateTodoTextBoundaries(t *testing.T) {
-	handler, _ := setupTestHandler()
+	handler, _ := setupTestHandler(t)
 
 	tests := []struct {
 		name       string
@@ -314,7 +313,7 @@ func TestCreateTodoTextBoundaries(t *testing.T) {
 			c.Request, _ = http.NewRequest("POST", "/api/todos", bytes.NewBuffer(body))
 			c.Request.Header.Set("Content-Type", "application/json")
 			
-			handler.CreateTodo(c)
+			Wrap(handler.CreateTodo)(c)
 
 			assert.Equal(t, tt.expectCode, w.Code)
 		})
@@ -322,7 +321,7 @@ func TestCreateTodoTextBoundaries(t *testing.T) {
 }
 
 func TestUpdateTodoWithEmptyUpdate(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	created := svc.Create(models.TodoCreate{
 		Text:     "Original",
@@ -339,7 +338,7 @@ func TestUpdateTodoWithEmptyUpdate(t *testing.T) {
 	c.Request, _ = http.NewRequest("PUT", "/api/todos/"+created.ID, bytes.NewBuffer(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 	
-	handler.UpdateTodo(c)
+	Wrap(handler.UpdateTodo)(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	
@@ -351,7 +350,7 @@ func TestUpdateTodoWithEmptyUpdate(t *testing.T) {
 }
 
 func TestDeleteSameTodoTwice(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	created := svc.Create(models.TodoCreate{Text: "To Delete"})
 
@@ -371,7 +370,7 @@ func TestDeleteSameTodoTwice(t *testing.T) {
 }
 
 func TestGetTodosWithInvalidFilter(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	svc.Create(models.TodoCreate{Text: "Test", Completed: false})
 
@@ -379,7 +378,7 @@ func TestGetTodosWithInvalidFilter(t *testing.T) {
 	c, _ := gin.CreateTestContext(w)
 	c.Request, _ = http.NewRequest("GET", "/api/todos?filter=invalid", nil)
 	
-	handler.GetTodos(c)
+	Wrap(handler.GetTodos)(c)
 
 	// Should still work, just ignore invalid filter
 	assert.Equal(t, http.StatusOK, w.Code)
@@ -390,7 +389,7 @@ func TestGetTodosWithInvalidFilter(t *testing.T) {
 }
 
 func TestToggleRapidSuccession(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	created := svc.Create(models.TodoCreate{Text: "Test", Completed: false})
 
@@ -399,7 +398,7 @@ func TestToggleRapidSuccession(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Params = gin.Params{{Key: "id", Value: created.ID}}
-		handler.ToggleTodo(c)
+		Wrap(handler.ToggleTodo)(c)
 		assert.Equal(t, http.StatusOK, w.Code)
 	}
 
@@ -409,7 +408,7 @@ func TestToggleRapidSuccession(t *testing.T) {
 }
 
 func TestCreateTodoWithLongTagList(t *testing.T) {
-	handler, _ := setupTestHandler()
+	handler, _ := setupTestHandler(t)
 
 	// Create todo with many tags
 	tags := make([]string, 50)
@@ -429,7 +428,7 @@ func TestCreateTodoWithLongTagList(t *testing.T) {
 	c.Request, _ = http.NewRequest("POST", "/api/todos", bytes.NewBuffer(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 	
-	handler.CreateTodo(c)
+	Wrap(handler.CreateTodo)(c)
 
 	assert.Equal(t, http.StatusCreated, w.Code)
 	
@@ -439,7 +438,7 @@ func TestCreateTodoWithLongTagList(t *testing.T) {
 }
 
 func TestUpdateTodoChangePriorityMultipleTimes(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	created := svc.Create(models.TodoCreate{Text: "Test"})
 
@@ -460,7 +459,7 @@ func TestUpdateTodoChangePriorityMultipleTimes(t *testing.T) {
 		c.Request, _ = http.NewRequest("PUT", "/api/todos/"+created.ID, bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 		
-		handler.UpdateTodo(c)
+		Wrap(handler.UpdateTodo)(c)
 		assert.Equal(t, http.StatusOK, w.Code)
 	}
 
@@ -470,13 +469,13 @@ func TestUpdateTodoChangePriorityMultipleTimes(t *testing.T) {
 }
 
 func TestGetStatsWithNoTodos(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	svc.ClearCompleted() // Clear sample data
 	
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	
-	handler.GetStats(c)
+	Wrap(handler.GetStats)(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	
@@ -490,7 +489,7 @@ func TestGetStatsWithNoTodos(t *testing.T) {
 }
 
 func TestCreateUpdateDeleteLifecycle(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 
 	// 1. Create
 	input := models.TodoCreate{Text: "Lifecycle Test", Priority: models.PriorityMedium}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"spicytodo-go-api/models"
 	"spicytodo-go-api/service"
 	"testing"
@@ -13,8 +14,14 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func setupTestHandler() (*TodoHandler, *service.TodoService) {
+// setupTestHandler returns a handler backed by a TodoService whose event
+// log is a private temp file, so this test can't leak state into (or
+// read state left by) another test via the shared default
+// data/events.log path.
+func setupTestHandler(t *testing.T) (*TodoHandler, *service.TodoService) {
+	t.Helper()
 	gin.SetMode(gin.TestMode)
+	t.Setenv("EVENTS_LOG_PATH", filepath.Join(t.TempDir(), "events.log"))
 	svc := service.NewTodoService()
 	svc.ClearCompleted() // Clear sample data for clean tests
 	handler := NewTodoHandler(svc)
@@ -22,7 +29,7 @@ func setupTestHandler() (*TodoHandler, *service.TodoService) {
 }
 
 func TestGetRoot(t *testing.T) {
-	handler, _ := setupTestHandler()
+	handler, _ := setupTestHandler(t)
 	
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -38,7 +45,7 @@ func TestGetRoot(t *testing.T) {
 }
 
 func TestGetHealth(t *testing.T) {
-	handler, _ := setupTestHandler()
+	handler, _ := setupTestHandler(t)
 	
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -54,7 +61,7 @@ func TestGetHealth(t *testing.T) {
 }
 
 func TestGetTodos(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	// Create test todos
 	svc.Create(models.TodoCreate{Text: "Todo 1", Priority: models.PriorityHigh, Completed: false})
@@ -64,7 +71,7 @@ func TestGetTodos(t *testing.T) {
 	c, _ := gin.CreateTestContext(w)
 	c.Request, _ = http.NewRequest("GET", "/api/todos", nil)
 	
-	handler.GetTodos(c)
+	Wrap(handler.GetTodos)(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	
@@ -75,7 +82,7 @@ func TestGetTodos(t *testing.T) {
 }
 
 func TestGetTodosWithFilter(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	svc.Create(models.TodoCreate{Text: "Active Todo", Completed: false})
 	svc.Create(models.TodoCreate{Text: "Completed Todo", Completed: true})
@@ -96,7 +103,7 @@ func TestGetTodosWithFilter(t *testing.T) {
 			c, _ := gin.CreateTestContext(w)
 			c.Request, _ = http.NewRequest("GET", "/api/todos?filter="+tt.filter, nil)
 			
-			handler.GetTodos(c)
+			Wrap(handler.GetTodos)(c)
 
 			var todos []models.Todo
 			json.Unmarshal(w.Body.Bytes(), &todos)
@@ -106,7 +113,7 @@ func TestGetTodosWithFilter(t *testing.T) {
 }
 
 func TestCreateTodo(t *testing.T) {
-	handler, _ := setupTestHandler()
+	handler, _ := setupTestHandler(t)
 
 	input := models.TodoCreate{
 		Text:     "New Todo",
@@ -120,7 +127,7 @@ func TestCreateTodo(t *testing.T) {
 	c.Request, _ = http.NewRequest("POST", "/api/todos", bytes.NewBuffer(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 	
-	handler.CreateTodo(c)
+	Wrap(handler.CreateTodo)(c)
 
 	assert.Equal(t, http.StatusCreated, w.Code)
 	
@@ -133,7 +140,7 @@ func TestCreateTodo(t *testing.T) {
 }
 
 func TestCreateTodoInvalidInput(t *testing.T) {
-	handler, _ := setupTestHandler()
+	handler, _ := setupTestHandler(t)
 
 	// Empty text
 	input := models.TodoCreate{
@@ -147,13 +154,13 @@ func TestCreateTodoInvalidInput(t *testing.T) {
 	c.Request, _ = http.NewRequest("POST", "/api/todos", bytes.NewBuffer(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 	
-	handler.CreateTodo(c)
+	Wrap(handler.CreateTodo)(c)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
 func TestGetTodoByID(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	created := svc.Create(models.TodoCreate{Text: "Test Todo"})
 
@@ -161,7 +168,7 @@ func TestGetTodoByID(t *testing.T) {
 	c, _ := gin.CreateTestContext(w)
 	c.Params = gin.Params{{Key: "id", Value: created.ID}}
 	
-	handler.GetTodoByID(c)
+	Wrap(handler.GetTodoByID)(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	
@@ -172,19 +179,19 @@ func TestGetTodoByID(t *testing.T) {
 }
 
 func TestGetTodoByIDNotFound(t *testing.T) {
-	handler, _ := setupTestHandler()
+	handler, _ := setupTestHandler(t)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	c.Params = gin.Params{{Key: "id", Value: "non-existent"}}
 	
-	handler.GetTodoByID(c)
+	Wrap(handler.GetTodoByID)(c)
 
 	assert.Equal(t, http.StatusNotFound, w.Code)
 }
 
 func TestUpdateTodo(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	created := svc.Create(models.TodoCreate{Text: "Original"})
 
@@ -201,7 +208,7 @@ func TestUpdateTodo(t *testing.T) {
 	c.Request, _ = http.NewRequest("PUT", "/api/todos/"+created.ID, bytes.NewBuffer(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 	
-	handler.UpdateTodo(c)
+	Wrap(handler.UpdateTodo)(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	
@@ -212,7 +219,7 @@ func TestUpdateTodo(t *testing.T) {
 }
 
 func TestUpdateTodoNotFound(t *testing.T) {
-	handler, _ := setupTestHandler()
+	handler, _ := setupTestHandler(t)
 
 	text := "Updated"
 	input := models.TodoUpdate{Text: &text}
@@ -224,13 +231,13 @@ func TestUpdateTodoNotFound(t *testing.T) {
 	c.Request, _ = http.NewRequest("PUT", "/api/todos/non-existent", bytes.NewBuffer(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 	
-	handler.UpdateTodo(c)
+	Wrap(handler.UpdateTodo)(c)
 
 	assert.Equal(t, http.StatusNotFound, w.Code)
 }
 
 func TestDeleteTodo(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	created := svc.Create(models.TodoCreate{Text: "To Delete"})
 
@@ -238,7 +245,7 @@ func TestDeleteTodo(t *testing.T) {
 	c, _ := gin.CreateTestContext(w)
 	c.Params = gin.Params{{Key: "id", Value: created.ID}}
 	
-	handler.DeleteTodo(c)
+	Wrap(handler.DeleteTodo)(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	
@@ -248,19 +255,19 @@ func TestDeleteTodo(t *testing.T) {
 }
 
 func TestDeleteTodoNotFound(t *testing.T) {
-	handler, _ := setupTestHandler()
+	handler, _ := setupTestHandler(t)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	c.Params = gin.Params{{Key: "id", Value: "non-existent"}}
 	
-	handler.DeleteTodo(c)
+	Wrap(handler.DeleteTodo)(c)
 
 	assert.Equal(t, http.StatusNotFound, w.Code)
 }
 
 func TestToggleTodo(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	created := svc.Create(models.TodoCreate{Text: "To Toggle", Completed: false})
 
@@ -268,7 +275,7 @@ func TestToggleTodo(t *testing.T) {
 	c, _ := gin.CreateTestContext(w)
 	c.Params = gin.Params{{Key: "id", Value: created.ID}}
 	
-	handler.ToggleTodo(c)
+	Wrap(handler.ToggleTodo)(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	
@@ -279,19 +286,19 @@ func TestToggleTodo(t *testing.T) {
 }
 
 func TestToggleTodoNotFound(t *testing.T) {
-	handler, _ := setupTestHandler()
+	handler, _ := setupTestHandler(t)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	c.Params = gin.Params{{Key: "id", Value: "non-existent"}}
 	
-	handler.ToggleTodo(c)
+	Wrap(handler.ToggleTodo)(c)
 
 	assert.Equal(t, http.StatusNotFound, w.Code)
 }
 
 func TestGetStats(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	svc.Create(models.TodoCreate{Text: "Todo 1", Completed: false})
 	svc.Create(models.TodoCreate{Text: "Todo 2", Completed: true})
@@ -299,7 +306,7 @@ func TestGetStats(t *testing.T) {
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	
-	handler.GetStats(c)
+	Wrap(handler.GetStats)(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	
@@ -312,7 +319,7 @@ func TestGetStats(t *testing.T) {
 }
 
 func TestClearCompleted(t *testing.T) {
-	handler, svc := setupTestHandler()
+	handler, svc := setupTestHandler(t)
 	
 	svc.Create(models.TodoCreate{Text: "Active", Completed: false})
 	svc.Create(models.TodoCreate{Text: "Completed", Completed: true})
@@ -320,7 +327,7 @@ func TestClearCompleted(t *testing.T) {
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	
-	handler.ClearCompleted(c)
+	Wrap(handler.ClearCompleted)(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	
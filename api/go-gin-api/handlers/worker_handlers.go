@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+	"spicytodo-go-api/scheduler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetWorkerQueues summarizes the background job queue's pending and
+// dead-lettered jobs by type.
+func (h *TodoHandler) GetWorkerQueues(c *gin.Context) error {
+	summary, err := h.service.QueueSummary()
+	if err != nil {
+		return err
+	}
+	c.JSON(http.StatusOK, summary)
+	return nil
+}
+
+// GetWorkerTask looks up a single job by ID, pending or dead-lettered.
+func (h *TodoHandler) GetWorkerTask(c *gin.Context) error {
+	id := c.Param("id")
+
+	job, exists, err := h.service.GetTask(id)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return notFound("Task not found")
+	}
+
+	c.JSON(http.StatusOK, job)
+	return nil
+}
+
+// RetryWorkerTask moves a dead-lettered job back to pending for another
+// attempt.
+func (h *TodoHandler) RetryWorkerTask(c *gin.Context) error {
+	id := c.Param("id")
+
+	job, err := h.service.RetryTask(id)
+	if err != nil {
+		if err == scheduler.ErrJobNotFound {
+			return notFound("Dead-lettered task not found")
+		}
+		return err
+	}
+
+	c.JSON(http.StatusOK, job)
+	return nil
+}
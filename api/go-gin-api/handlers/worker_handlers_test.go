@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"spicytodo-go-api/models"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetWorkerQueuesCountsPendingJobs(t *testing.T) {
+	handler, svc := setupTestHandler(t)
+
+	dueDate := time.Now().Format("2006-01-02")
+	svc.Create(models.TodoCreate{
+		Text:      "Remind me",
+		DueDate:   &dueDate,
+		Reminders: []models.Reminder{{RelativeTo: models.ReminderAnchorDueDate, OffsetSeconds: 9 * 3600}},
+	})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/api/worker/queues", nil)
+
+	Wrap(handler.GetWorkerQueues)(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var summary map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &summary))
+	assert.EqualValues(t, 1, summary["pendingTotal"])
+}
+
+func TestGetWorkerTaskFindsPendingJob(t *testing.T) {
+	handler, svc := setupTestHandler(t)
+
+	dueDate := time.Now().Format("2006-01-02")
+	svc.Create(models.TodoCreate{
+		Text:      "Remind me",
+		DueDate:   &dueDate,
+		Reminders: []models.Reminder{{RelativeTo: models.ReminderAnchorDueDate, OffsetSeconds: 9 * 3600}},
+	})
+
+	jobs, err := svc.PendingJobs()
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/api/worker/tasks/"+jobs[0].ID, nil)
+	c.Params = gin.Params{{Key: "id", Value: jobs[0].ID}}
+
+	Wrap(handler.GetWorkerTask)(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestGetWorkerTaskUnknownIDReturnsNotFound(t *testing.T) {
+	handler, _ := setupTestHandler(t)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/api/worker/tasks/missing", nil)
+	c.Params = gin.Params{{Key: "id", Value: "missing"}}
+
+	Wrap(handler.GetWorkerTask)(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRetryWorkerTaskUnknownIDReturnsNotFound(t *testing.T) {
+	handler, _ := setupTestHandler(t)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/api/worker/tasks/missing/retry", nil)
+	c.Params = gin.Params{{Key: "id", Value: "missing"}}
+
+	Wrap(handler.RetryWorkerTask)(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"spicytodo-go-api/handlers"
 	"spicytodo-go-api/models"
 	"spicytodo-go-api/routes"
@@ -16,20 +17,24 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func setupIntegrationTest() *gin.Engine {
+// setupIntegrationTest wires up a router against a TodoService whose event
+// log is a private temp file, so this test can't leak state into (or read
+// state left by) another test via the shared default data/events.log path.
+func setupIntegrationTest(t *testing.T) *gin.Engine {
 	gin.SetMode(gin.TestMode)
-	
+	t.Setenv("EVENTS_LOG_PATH", filepath.Join(t.TempDir(), "events.log"))
+
 	todoService := service.NewTodoService()
 	todoHandler := handlers.NewTodoHandler(todoService)
-	
+
 	router := gin.Default()
 	routes.SetupRoutes(router, todoHandler)
-	
+
 	return router
 }
 
 func TestFullAPIWorkflow(t *testing.T) {
-	router := setupIntegrationTest()
+	router := setupIntegrationTest(t)
 
 	// 1. Health check
 	req1, _ := http.NewRequest("GET", "/health", nil)
@@ -95,7 +100,7 @@ func TestFullAPIWorkflow(t *testing.T) {
 }
 
 func TestTagWorkflow(t *testing.T) {
-	router := setupIntegrationTest()
+	router := setupIntegrationTest(t)
 
 	// Create todos with tags
 	createBody1, _ := json.Marshal(models.TodoCreate{
@@ -136,7 +141,7 @@ func TestTagWorkflow(t *testing.T) {
 }
 
 func TestSnoozeWorkflow(t *testing.T) {
-	router := setupIntegrationTest()
+	router := setupIntegrationTest(t)
 
 	// Create todo
 	createBody, _ := json.Marshal(models.TodoCreate{Text: "To Snooze"})
@@ -198,7 +203,7 @@ func TestSnoozeWorkflow(t *testing.T) {
 }
 
 func TestBulkOperationWorkflow(t *testing.T) {
-	router := setupIntegrationTest()
+	router := setupIntegrationTest(t)
 
 	// Create multiple todos
 	ids := make([]string, 3)
@@ -240,7 +245,7 @@ func TestBulkOperationWorkflow(t *testing.T) {
 }
 
 func TestExportImportWorkflow(t *testing.T) {
-	router := setupIntegrationTest()
+	router := setupIntegrationTest(t)
 
 	// Create initial todos
 	category := "Export Test"
@@ -293,7 +298,7 @@ func TestExportImportWorkflow(t *testing.T) {
 }
 
 func TestCORSHeaders(t *testing.T) {
-	router := setupIntegrationTest()
+	router := setupIntegrationTest(t)
 
 	req, _ := http.NewRequest("OPTIONS", "/api/todos", nil)
 	req.Header.Set("Origin", "http://localhost:3000")
@@ -306,7 +311,7 @@ func TestCORSHeaders(t *testing.T) {
 }
 
 func TestErrorResponseFormat(t *testing.T) {
-	router := setupIntegrationTest()
+	router := setupIntegrationTest(t)
 
 	// Try to get non-existent todo
 	req, _ := http.NewRequest("GET", "/api/todos/non-existent-id", nil)
@@ -314,16 +319,17 @@ func TestErrorResponseFormat(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusNotFound, w.Code)
-	
+
 	var response map[string]interface{}
 	json.Unmarshal(w.Body.Bytes(), &response)
-	
-	assert.Contains(t, response, "error")
-	assert.NotEmpty(t, response["error"])
+
+	assert.Contains(t, response, "code")
+	assert.Contains(t, response, "message")
+	assert.NotEmpty(t, response["message"])
 }
 
 func TestMultipleSimultaneousRequests(t *testing.T) {
-	router := setupIntegrationTest()
+	router := setupIntegrationTest(t)
 
 	done := make(chan bool, 10)
 
@@ -345,7 +351,7 @@ func TestMultipleSimultaneousRequests(t *testing.T) {
 }
 
 func TestRecurringTodoCompleteWorkflow(t *testing.T) {
-	router := setupIntegrationTest()
+	router := setupIntegrationTest(t)
 
 	// Create recurring todo
 	dueDate := time.Now().Format("2006-01-02")
@@ -378,12 +384,19 @@ func TestRecurringTodoCompleteWorkflow(t *testing.T) {
 	router.ServeHTTP(w3, req3)
 	assert.Equal(t, http.StatusOK, w3.Code)
 
-	// Note: ProcessRecurringTodos needs to be called
-	// In production, this would be a background job
+	// Completing a recurring todo now materializes its next occurrence
+	// immediately (TodoService.Toggle), no background job required.
+	req4, _ := http.NewRequest("GET", "/api/todos", nil)
+	w4 := httptest.NewRecorder()
+	router.ServeHTTP(w4, req4)
+
+	var afterToggle []models.Todo
+	json.Unmarshal(w4.Body.Bytes(), &afterToggle)
+	assert.Greater(t, len(afterToggle), initialCount)
 }
 
 func TestFilterCombinationsIntegration(t *testing.T) {
-	router := setupIntegrationTest()
+	router := setupIntegrationTest(t)
 
 	// Create diverse todos
 	todos := []models.TodoCreate{
@@ -429,7 +442,7 @@ func TestFilterCombinationsIntegration(t *testing.T) {
 }
 
 func TestImportReplaceMode(t *testing.T) {
-	router := setupIntegrationTest()
+	router := setupIntegrationTest(t)
 
 	// Get initial count
 	req1, _ := http.NewRequest("GET", "/api/todos", nil)
@@ -468,27 +481,58 @@ func TestImportReplaceMode(t *testing.T) {
 }
 
 func TestRateLimitSimulation(t *testing.T) {
-	router := setupIntegrationTest()
+	t.Setenv("RATE_LIMIT_READ_CAPACITY", "5")
+	t.Setenv("RATE_LIMIT_READ_REFILL", "0.0001")
+	router := setupIntegrationTest(t)
 
-	// Simulate many rapid requests
+	// Drive the read limiter's bucket (capacity 5) past its threshold.
 	successCount := 0
-	
-	for i := 0; i < 100; i++ {
-		req, _ := http.NewRequest("GET", "/health", nil)
+	var last *httptest.ResponseRecorder
+
+	for i := 0; i < 6; i++ {
+		req, _ := http.NewRequest("GET", "/api/todos", nil)
 		w := httptest.NewRecorder()
 		router.ServeHTTP(w, req)
-		
+		last = w
+
 		if w.Code == http.StatusOK {
 			successCount++
 		}
 	}
 
-	// All should succeed (no rate limiting yet)
-	assert.Equal(t, 100, successCount)
+	assert.Equal(t, 5, successCount)
+	assert.Equal(t, http.StatusTooManyRequests, last.Code)
+	assert.NotEmpty(t, last.Header().Get("Retry-After"))
+}
+
+func TestRateLimitIsPerClient(t *testing.T) {
+	t.Setenv("RATE_LIMIT_READ_CAPACITY", "1")
+	t.Setenv("RATE_LIMIT_READ_REFILL", "0.0001")
+	router := setupIntegrationTest(t)
+
+	req1, _ := http.NewRequest("GET", "/api/todos", nil)
+	req1.RemoteAddr = "10.1.1.1:5000"
+	w1 := httptest.NewRecorder()
+	router.ServeHTTP(w1, req1)
+	assert.Equal(t, http.StatusOK, w1.Code)
+
+	// Same client again: should now be throttled.
+	req1Again, _ := http.NewRequest("GET", "/api/todos", nil)
+	req1Again.RemoteAddr = "10.1.1.1:5000"
+	w1Again := httptest.NewRecorder()
+	router.ServeHTTP(w1Again, req1Again)
+	assert.Equal(t, http.StatusTooManyRequests, w1Again.Code)
+
+	// A different client's budget is untouched.
+	req2, _ := http.NewRequest("GET", "/api/todos", nil)
+	req2.RemoteAddr = "10.1.1.2:5000"
+	w2 := httptest.NewRecorder()
+	router.ServeHTTP(w2, req2)
+	assert.Equal(t, http.StatusOK, w2.Code)
 }
 
 func TestContentTypeValidation(t *testing.T) {
-	router := setupIntegrationTest()
+	router := setupIntegrationTest(t)
 
 	// Try to create todo without Content-Type
 	body, _ := json.Marshal(models.TodoCreate{Text: "Test"})
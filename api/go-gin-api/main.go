@@ -1,8 +1,16 @@
 package main
 
+//go:generate go run ./cmd/genopenapi
+
 import (
+	"context"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
 	"spicytodo-go-api/handlers"
 	"spicytodo-go-api/routes"
 	"spicytodo-go-api/service"
@@ -10,6 +18,59 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// shutdownGracePeriod bounds how long the server waits for in-flight
+// requests (e.g. a bulk operation or import) to finish before forcing
+// the listener closed.
+const shutdownGracePeriod = 10 * time.Second
+
+// jobQueuePollInterval is how often the background worker checks the job
+// queue (see TodoService.ProcessJobQueue) for due reminder/recurrence jobs.
+const jobQueuePollInterval = 5 * time.Second
+
+// caldavPullInterval is how often the background worker pulls remote
+// changes from the configured CalDAV server, in addition to the explicit
+// POST /api/sync/caldav/pull an operator can trigger.
+const caldavPullInterval = 5 * time.Minute
+
+// runCalDAVPullWorker periodically reconciles the local store from the
+// CalDAV server until ctx is cancelled. It's a no-op loop (besides the
+// CalDAVConfigured check each tick) when CALDAV_URL isn't set.
+func runCalDAVPullWorker(ctx context.Context, todoService *service.TodoService) {
+	if !todoService.CalDAVConfigured() {
+		return
+	}
+
+	ticker := time.NewTicker(caldavPullInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := todoService.PullCalDAV(); err != nil {
+				log.Printf("caldav: periodic pull: %v", err)
+			}
+		}
+	}
+}
+
+// runJobQueueWorker polls the job queue every jobQueuePollInterval until ctx
+// is cancelled, running whatever reminder/recurrence jobs have come due.
+func runJobQueueWorker(ctx context.Context, todoService *service.TodoService) {
+	ticker := time.NewTicker(jobQueuePollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			todoService.ProcessJobQueue()
+		}
+	}
+}
+
 func main() {
 	// Set Gin mode
 	if os.Getenv("GIN_MODE") == "" {
@@ -19,12 +80,37 @@ func main() {
 	// Initialize service
 	todoService := service.NewTodoService()
 
+	// bgCtx bounds the lifetime of background goroutines (the scheduler,
+	// rate limiter eviction sweeps) to the life of the process.
+	bgCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+
+	// Start the background scheduler that fires reminders and snooze
+	// expiries from its fire-time heap; it stops when main returns.
+	scheduler := service.NewScheduler(todoService)
+	todoService.SetScheduler(scheduler)
+	go scheduler.Run(bgCtx)
+
+	// Start the job queue worker: reminder and recurrence jobs enqueued by
+	// Create/Update/Toggle (see TodoService.ProcessJobQueue) are claimed and
+	// run here instead of waiting on a client to poll GetUpcomingReminders
+	// or ProcessRecurringTodos.
+	go runJobQueueWorker(bgCtx, todoService)
+
+	// Start the CalDAV pull worker: a no-op unless CALDAV_URL is set, in
+	// which case it periodically reconciles local todos against the server
+	// in addition to the push that already happens on every mutation.
+	go runCalDAVPullWorker(bgCtx, todoService)
+
 	// Initialize handler
 	todoHandler := handlers.NewTodoHandler(todoService)
 
 	// Setup router
 	router := gin.Default()
-	routes.SetupRoutes(router, todoHandler)
+	limiters := routes.SetupRoutes(router, todoHandler)
+	for _, limiter := range limiters {
+		go limiter.Sweep(bgCtx)
+	}
 
 	// Get port from environment
 	port := os.Getenv("PORT")
@@ -33,9 +119,30 @@ func main() {
 	}
 
 	log.Printf("🌶️  Spicy Todo API (Go/Gin) running on http://localhost:%s", port)
-	
-	// Start server
-	if err := router.Run(":" + port); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+
+	srv := &http.Server{
+		Addr:    ":" + port,
+		Handler: router,
+	}
+
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Failed to start server: %v", err)
+		}
+	}()
+
+	// Wait for an interrupt/terminate signal, then give in-flight requests
+	// (bulk operations, imports) up to shutdownGracePeriod to finish before
+	// forcing the listener closed.
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	<-quit
+
+	log.Println("Shutting down server...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownGracePeriod)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Fatalf("Server forced to shutdown: %v", err)
 	}
 }
@@ -0,0 +1,26 @@
+// Package middleware holds Gin middleware shared across routes.
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultRequestDeadline bounds how long a single request may run before
+// handlers checking ctx.Done() (e.g. the bulk operation endpoints) abort.
+const DefaultRequestDeadline = 30 * time.Second
+
+// RequestDeadline attaches a timeout to c.Request's context for the
+// duration of the request, so long-running handlers can cooperatively
+// cancel in-flight work instead of running unbounded.
+func RequestDeadline(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,163 @@
+// Package ratelimit implements a token-bucket rate limiter middleware, with
+// one bucket per client key (by default, client IP) so a single abusive
+// caller can't starve everyone else's budget.
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// idleEvictAfter bounds how long a bucket survives without being touched,
+// so distinct one-off clients don't accumulate in memory forever.
+const idleEvictAfter = 10 * time.Minute
+
+// Policy configures one token bucket: Capacity tokens, refilled at
+// RefillRate tokens/sec, up to Capacity.
+type Policy struct {
+	Capacity   float64
+	RefillRate float64
+}
+
+// PolicyFromEnv returns def with Capacity/RefillRate overridden by the named
+// environment variables when they parse as floats, so limits can be tuned
+// (e.g. in tests) without recompiling.
+func PolicyFromEnv(capacityEnv, refillEnv string, def Policy) Policy {
+	if v, err := strconv.ParseFloat(os.Getenv(capacityEnv), 64); err == nil {
+		def.Capacity = v
+	}
+	if v, err := strconv.ParseFloat(os.Getenv(refillEnv), 64); err == nil {
+		def.RefillRate = v
+	}
+	return def
+}
+
+// KeyFunc derives the bucket key for a request.
+type KeyFunc func(c *gin.Context) string
+
+// DefaultKeyFunc keys by the first hop of X-Forwarded-For, falling back to
+// the connection's remote address.
+func DefaultKeyFunc(c *gin.Context) string {
+	if xff := c.GetHeader("X-Forwarded-For"); xff != "" {
+		if i := strings.IndexByte(xff, ','); i >= 0 {
+			return strings.TrimSpace(xff[:i])
+		}
+		return strings.TrimSpace(xff)
+	}
+	return c.Request.RemoteAddr
+}
+
+type bucket struct {
+	mu         sync.Mutex
+	tokens     float64
+	lastRefill time.Time
+	lastAccess time.Time
+}
+
+// take refills the bucket for elapsed time, then attempts to consume one
+// token. It reports whether the request is allowed, the tokens remaining
+// afterward, and (when denied) how long until one is next available.
+func (b *bucket) take(policy Policy) (allowed bool, remaining float64, retryAfter time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens += now.Sub(b.lastRefill).Seconds() * policy.RefillRate
+	if b.tokens > policy.Capacity {
+		b.tokens = policy.Capacity
+	}
+	b.lastRefill = now
+	b.lastAccess = now
+
+	if b.tokens < 1 {
+		deficit := 1 - b.tokens
+		return false, b.tokens, time.Duration(deficit / policy.RefillRate * float64(time.Second))
+	}
+
+	b.tokens--
+	return true, b.tokens, 0
+}
+
+// Limiter enforces a Policy across a set of per-key token buckets.
+type Limiter struct {
+	policy  Policy
+	keyFunc KeyFunc
+	buckets sync.Map // key string -> *bucket
+}
+
+// NewLimiter builds a Limiter for policy, keying buckets with keyFunc
+// (DefaultKeyFunc if nil).
+func NewLimiter(policy Policy, keyFunc KeyFunc) *Limiter {
+	if keyFunc == nil {
+		keyFunc = DefaultKeyFunc
+	}
+	return &Limiter{policy: policy, keyFunc: keyFunc}
+}
+
+// Middleware rejects requests past the policy's rate with 429 and a
+// Retry-After header; every request, allowed or not, gets X-RateLimit-*
+// headers describing its remaining budget.
+func (l *Limiter) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := l.keyFunc(c)
+		value, _ := l.buckets.LoadOrStore(key, &bucket{
+			tokens:     l.policy.Capacity,
+			lastRefill: time.Now(),
+			lastAccess: time.Now(),
+		})
+		b := value.(*bucket)
+
+		allowed, remaining, retryAfter := b.take(l.policy)
+
+		c.Header("X-RateLimit-Limit", strconv.FormatFloat(l.policy.Capacity, 'f', 0, 64))
+		c.Header("X-RateLimit-Remaining", strconv.FormatFloat(remaining, 'f', 0, 64))
+		c.Header("X-RateLimit-Reset", strconv.FormatFloat(1/l.policy.RefillRate, 'f', 2, 64))
+
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Rate limit exceeded"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// Sweep runs until ctx is cancelled, periodically evicting buckets idle
+// longer than idleEvictAfter so memory doesn't grow unbounded as distinct
+// clients come and go.
+func (l *Limiter) Sweep(ctx context.Context) {
+	ticker := time.NewTicker(idleEvictAfter / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			l.evictIdle()
+		}
+	}
+}
+
+func (l *Limiter) evictIdle() {
+	now := time.Now()
+	l.buckets.Range(func(key, value interface{}) bool {
+		b := value.(*bucket)
+		b.mu.Lock()
+		idle := now.Sub(b.lastAccess)
+		b.mu.Unlock()
+
+		if idle > idleEvictAfter {
+			l.buckets.Delete(key)
+		}
+		return true
+	})
+}
@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newTestRouter(limiter *Limiter) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/limited", limiter.Middleware(), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	return router
+}
+
+func TestMiddlewareAllowsUpToCapacity(t *testing.T) {
+	limiter := NewLimiter(Policy{Capacity: 2, RefillRate: 0.0001}, nil)
+	router := newTestRouter(limiter)
+
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest("GET", "/limited", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d: got status %d, want 200", i, w.Code)
+		}
+	}
+
+	req, _ := http.NewRequest("GET", "/limited", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("3rd request: got status %d, want 429", w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("expected Retry-After header on a throttled response")
+	}
+}
+
+func TestMiddlewareKeysByClient(t *testing.T) {
+	limiter := NewLimiter(Policy{Capacity: 1, RefillRate: 0.0001}, nil)
+	router := newTestRouter(limiter)
+
+	req1, _ := http.NewRequest("GET", "/limited", nil)
+	req1.RemoteAddr = "10.0.0.1:1"
+	w1 := httptest.NewRecorder()
+	router.ServeHTTP(w1, req1)
+	if w1.Code != http.StatusOK {
+		t.Fatalf("client 1 first request: got %d, want 200", w1.Code)
+	}
+
+	req2, _ := http.NewRequest("GET", "/limited", nil)
+	req2.RemoteAddr = "10.0.0.2:1"
+	w2 := httptest.NewRecorder()
+	router.ServeHTTP(w2, req2)
+	if w2.Code != http.StatusOK {
+		t.Errorf("a different client should be unaffected by client 1's usage, got %d", w2.Code)
+	}
+}
+
+func TestPolicyFromEnvOverrides(t *testing.T) {
+	t.Setenv("RL_TEST_CAPACITY", "42")
+	t.Setenv("RL_TEST_REFILL", "7")
+
+	policy := PolicyFromEnv("RL_TEST_CAPACITY", "RL_TEST_REFILL", Policy{Capacity: 1, RefillRate: 1})
+
+	if policy.Capacity != 42 || policy.RefillRate != 7 {
+		t.Errorf("PolicyFromEnv() = %+v, want {42 7}", policy)
+	}
+}
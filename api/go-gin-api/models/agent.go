@@ -0,0 +1,14 @@
+package models
+
+// Agent is a worker that todos can be assigned to. Its Labels are matched
+// against a todo's own Labels by the scoring filter in
+// service/assignment.go to decide whether, and how well, it fits a task.
+type Agent struct {
+	ID     string            `json:"id"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type AgentCreate struct {
+	ID     string            `json:"id" binding:"required,min=1,max=200"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
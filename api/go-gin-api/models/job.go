@@ -0,0 +1,12 @@
+package models
+
+// JobStatus reflects where a scheduled background job (see the scheduler
+// package) is in its lifecycle.
+type JobStatus string
+
+const (
+	JobStatusPending    JobStatus = "pending"
+	JobStatusDone       JobStatus = "done"
+	JobStatusFailed     JobStatus = "failed"
+	JobStatusDeadLetter JobStatus = "dead-letter"
+)
@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// MaintenanceSchedule describes when a MaintenanceWindow is active: either a
+// single fixed window (Start/End), or a repeating one anchored at Start and
+// expanded via an iCalendar RRULE, each occurrence lasting Duration.
+type MaintenanceSchedule struct {
+	// Start is the window's own start for a one-shot schedule, or the
+	// RRULE's DTSTART (first occurrence) for a repeating one.
+	Start time.Time `json:"start"`
+	// End closes a one-shot schedule. Ignored (and unused) once Recurrence
+	// is set.
+	End *time.Time `json:"end,omitempty"`
+	// Recurrence holds an RRULE value (e.g. "FREQ=WEEKLY;BYDAY=SA"). Empty
+	// means Start/End describe a single fixed window rather than a
+	// repeating one.
+	Recurrence string `json:"recurrence,omitempty"`
+	// Duration is how long each occurrence lasts, for a repeating
+	// schedule. Ignored for a one-shot schedule, which uses End instead.
+	Duration time.Duration `json:"durationNanos,omitempty"`
+}
+
+// MaintenanceTarget selects which todos a MaintenanceWindow applies to. All
+// is checked first; otherwise a todo matches if its ID is in TodoIDs, any of
+// its Tags is in Tags, or its Category is in Categories. Leaving every field
+// empty matches nothing, not everything — use All for that.
+type MaintenanceTarget struct {
+	All        bool     `json:"all,omitempty"`
+	TodoIDs    []string `json:"todoIds,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	Categories []string `json:"categories,omitempty"`
+}
+
+// MaintenanceWindow is a planned maintenance period during which reminders
+// for its targeted todos are suppressed and recurrence materialization is
+// deferred. See MaintenanceService.IsActive for how Schedule and Target are
+// evaluated.
+type MaintenanceWindow struct {
+	ID          string              `json:"id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description,omitempty"`
+	Schedule    MaintenanceSchedule `json:"schedule"`
+	Target      MaintenanceTarget   `json:"target"`
+	CreatedAt   time.Time           `json:"createdAt"`
+	UpdatedAt   time.Time           `json:"updatedAt"`
+}
+
+type MaintenanceWindowCreate struct {
+	Name        string              `json:"name" binding:"required,min=1,max=200"`
+	Description string              `json:"description,omitempty"`
+	Schedule    MaintenanceSchedule `json:"schedule"`
+	Target      MaintenanceTarget   `json:"target"`
+}
+
+type MaintenanceWindowUpdate struct {
+	Name        *string              `json:"name,omitempty" binding:"omitempty,min=1,max=200"`
+	Description *string              `json:"description,omitempty"`
+	Schedule    *MaintenanceSchedule `json:"schedule,omitempty"`
+	Target      *MaintenanceTarget   `json:"target,omitempty"`
+}
@@ -1,12 +1,57 @@
 package models
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+// maxReminderWindow bounds how far apart From and To can be, so a client
+// can't turn GetUpcomingReminders into an unbounded full-table scan.
+const maxReminderWindow = 30 * 24 * time.Hour
+
+// ReminderWindow bounds a GetUpcomingReminders query. From/To are normally
+// populated by SetDefaults from the `from`/`to` query params (RFC3339 or
+// unix seconds); zero values there mean "use the default".
+type ReminderWindow struct {
+	From time.Time
+	To   time.Time
+	// IncludeSnoozed surfaces todos whose SnoozedUntil falls inside the
+	// window alongside ones with a Reminder firing in it.
+	IncludeSnoozed bool
+}
+
+// SetDefaults fills in a zero To as now+24h and a zero From as To-24h, then
+// validates From < To and caps the span at maxReminderWindow.
+func (w *ReminderWindow) SetDefaults(now time.Time) error {
+	if w.To.IsZero() {
+		w.To = now.Add(24 * time.Hour)
+	}
+	if w.From.IsZero() {
+		w.From = w.To.Add(-24 * time.Hour)
+	}
+
+	if !w.From.Before(w.To) {
+		return errors.New("from must be before to")
+	}
+	if w.To.Sub(w.From) > maxReminderWindow {
+		return errors.New("window exceeds the maximum of 30 days")
+	}
+	return nil
+}
 
 // SnoozeRequest for snoozing a todo
 type SnoozeRequest struct {
 	Until time.Time `json:"until" binding:"required"`
 }
 
+// RecurrenceRequest sets a todo's recurrence. Recurrence (an iCalendar-style
+// RRULE) takes precedence over RecurrenceRule when both are given, matching
+// Todo's own field precedence for computing the next occurrence.
+type RecurrenceRequest struct {
+	RecurrenceRule RecurrenceRule `json:"recurrenceRule" binding:"required"`
+	Recurrence     string         `json:"recurrence,omitempty"`
+}
+
 // BulkOperation for bulk operations on todos
 type BulkOperation struct {
 	IDs       []string               `json:"ids" binding:"required"`
@@ -14,7 +59,8 @@ type BulkOperation struct {
 	Data      map[string]interface{} `json:"data,omitempty"`
 }
 
-// ExportQuery for export filtering
+// ExportQuery for export filtering. Format is one of "json" (default),
+// "csv", "ndjson", "md", or "ics" (alias "ical").
 type ExportQuery struct {
 	Format string `form:"format"`
 	Filter string `form:"filter"`
@@ -33,6 +79,10 @@ type ImportResult struct {
 	Skipped    int      `json:"skipped"`
 	Errors     []string `json:"errors"`
 	ImportedAt string   `json:"importedAt"`
+	// Cancelled is true when the request's deadline/disconnect aborted
+	// processing partway through; Imported/Skipped still reflect the work
+	// done up to that point.
+	Cancelled bool `json:"cancelled,omitempty"`
 }
 
 // ExportResult response
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReminderWindowSetDefaultsFillsBothEnds(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	var window ReminderWindow
+	if err := window.SetDefaults(now); err != nil {
+		t.Fatalf("SetDefaults: %v", err)
+	}
+
+	if !window.To.Equal(now.Add(24 * time.Hour)) {
+		t.Errorf("To = %v, want now+24h", window.To)
+	}
+	if !window.From.Equal(window.To.Add(-24 * time.Hour)) {
+		t.Errorf("From = %v, want To-24h", window.From)
+	}
+}
+
+func TestReminderWindowSetDefaultsKeepsExplicitValues(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	from := now.Add(2 * time.Hour)
+
+	window := ReminderWindow{From: from}
+	if err := window.SetDefaults(now); err != nil {
+		t.Fatalf("SetDefaults: %v", err)
+	}
+
+	if !window.From.Equal(from) {
+		t.Errorf("From = %v, want unchanged %v", window.From, from)
+	}
+	if !window.To.Equal(now.Add(24 * time.Hour)) {
+		t.Errorf("To = %v, want now+24h", window.To)
+	}
+}
+
+func TestReminderWindowSetDefaultsRejectsFromAfterTo(t *testing.T) {
+	now := time.Now()
+	window := ReminderWindow{From: now.Add(time.Hour), To: now}
+
+	if err := window.SetDefaults(now); err == nil {
+		t.Error("expected an error when From is not before To")
+	}
+}
+
+func TestReminderWindowSetDefaultsCapsWindowLength(t *testing.T) {
+	now := time.Now()
+	window := ReminderWindow{From: now, To: now.Add(31 * 24 * time.Hour)}
+
+	if err := window.SetDefaults(now); err == nil {
+		t.Error("expected an error for a window longer than 30 days")
+	}
+}
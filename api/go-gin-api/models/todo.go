@@ -19,30 +19,69 @@ const (
 	RecurrenceMonthly RecurrenceRule = "monthly"
 )
 
+// ReminderAnchor names which of a todo's own dates a relative Reminder is
+// offset from.
+type ReminderAnchor string
+
+const (
+	ReminderAnchorDueDate   ReminderAnchor = "dueDate"
+	ReminderAnchorStartDate ReminderAnchor = "startDate"
+)
+
+// Reminder fires at an absolute time (At), or at OffsetSeconds from one of
+// the todo's own anchor dates (RelativeTo), e.g. OffsetSeconds: -3600 with
+// RelativeTo: ReminderAnchorDueDate for "one hour before due". Exactly one
+// of At or RelativeTo is expected to be set.
+type Reminder struct {
+	At            *time.Time     `json:"at,omitempty"`
+	RelativeTo    ReminderAnchor `json:"relativeTo,omitempty"`
+	OffsetSeconds int            `json:"offsetSeconds,omitempty"`
+}
+
 type Todo struct {
 	ID             string         `json:"id"`
 	Text           string         `json:"text" binding:"required,min=1,max=500"`
 	Priority       Priority       `json:"priority"`
 	Completed      bool           `json:"completed"`
 	DueDate        *string        `json:"dueDate,omitempty"`
-	ReminderTime   *string        `json:"reminderTime,omitempty"`
+	StartDate      *string        `json:"startDate,omitempty"`
+	Reminders      []Reminder     `json:"reminders,omitempty"`
 	RecurrenceRule RecurrenceRule `json:"recurrenceRule"`
-	SnoozedUntil   *time.Time     `json:"snoozedUntil,omitempty"`
-	Tags           []string       `json:"tags,omitempty"`
-	Category       *string        `json:"category,omitempty"`
-	CreatedAt      time.Time      `json:"createdAt"`
-	UpdatedAt      time.Time      `json:"updatedAt"`
+	// Recurrence holds an iCalendar-style RRULE string (e.g.
+	// "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10"). When set, it takes
+	// precedence over RecurrenceRule for computing the next occurrence.
+	Recurrence string `json:"recurrence,omitempty"`
+	// RecurrenceParentID points at the todo this instance was spawned from,
+	// so occurrences of the same recurring series can be traced back.
+	RecurrenceParentID *string `json:"recurrenceParentId,omitempty"`
+	// ExceptionDates lists "2006-01-02" due dates (RFC 5545 EXDATE,
+	// restricted to DueDate's own date-only precision) that would
+	// otherwise match Recurrence/RecurrenceRule but should be skipped.
+	ExceptionDates []string   `json:"exceptionDates,omitempty"`
+	SnoozedUntil   *time.Time `json:"snoozedUntil,omitempty"`
+	Tags           []string   `json:"tags,omitempty"`
+	Category       *string    `json:"category,omitempty"`
+	// Labels are matched against a registered Agent's own Labels by the
+	// scoring filter in service/assignment.go to pick an assignee.
+	Labels    map[string]string `json:"labels,omitempty"`
+	Assignee  *string           `json:"assignee,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+	UpdatedAt time.Time         `json:"updatedAt"`
 }
 
 type TodoCreate struct {
-	Text           string         `json:"text" binding:"required,min=1,max=500"`
-	Priority       Priority       `json:"priority"`
-	Completed      bool           `json:"completed"`
-	DueDate        *string        `json:"dueDate,omitempty"`
-	ReminderTime   *string        `json:"reminderTime,omitempty"`
-	RecurrenceRule RecurrenceRule `json:"recurrenceRule"`
-	Tags           []string       `json:"tags,omitempty"`
-	Category       *string        `json:"category,omitempty"`
+	Text           string            `json:"text" binding:"required,min=1,max=500"`
+	Priority       Priority          `json:"priority"`
+	Completed      bool              `json:"completed"`
+	DueDate        *string           `json:"dueDate,omitempty"`
+	StartDate      *string           `json:"startDate,omitempty"`
+	Reminders      []Reminder        `json:"reminders,omitempty"`
+	RecurrenceRule RecurrenceRule    `json:"recurrenceRule"`
+	Recurrence     string            `json:"recurrence,omitempty"`
+	ExceptionDates []string          `json:"exceptionDates,omitempty"`
+	Tags           []string          `json:"tags,omitempty"`
+	Category       *string           `json:"category,omitempty"`
+	Labels         map[string]string `json:"labels,omitempty"`
 }
 
 type TodoUpdate struct {
@@ -50,8 +89,11 @@ type TodoUpdate struct {
 	Priority       *Priority       `json:"priority,omitempty"`
 	Completed      *bool           `json:"completed,omitempty"`
 	DueDate        *string         `json:"dueDate,omitempty"`
-	ReminderTime   *string         `json:"reminderTime,omitempty"`
+	StartDate      *string         `json:"startDate,omitempty"`
+	Reminders      []Reminder      `json:"reminders,omitempty"`
 	RecurrenceRule *RecurrenceRule `json:"recurrenceRule,omitempty"`
+	Recurrence     *string         `json:"recurrence,omitempty"`
+	ExceptionDates []string        `json:"exceptionDates,omitempty"`
 	Tags           []string        `json:"tags,omitempty"`
 	Category       *string         `json:"category,omitempty"`
 }
@@ -9,7 +9,7 @@ import (
 func TestTodoJSONSerialization(t *testing.T) {
 	now := time.Now()
 	dueDate := "2024-12-31"
-	reminderTime := "10:00"
+	reminderAt := now.Add(10 * time.Hour)
 	category := "Work"
 	snoozedUntil := time.Now().Add(2 * time.Hour)
 
@@ -19,7 +19,7 @@ func TestTodoJSONSerialization(t *testing.T) {
 		Priority:       PriorityHigh,
 		Completed:      false,
 		DueDate:        &dueDate,
-		ReminderTime:   &reminderTime,
+		Reminders:      []Reminder{{At: &reminderAt}},
 		RecurrenceRule: RecurrenceDaily,
 		SnoozedUntil:   &snoozedUntil,
 		Tags:           []string{"work", "urgent"},
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 )
@@ -28,17 +29,17 @@ func TestPriorityConstants(t *testing.T) {
 func TestTodoStructure(t *testing.T) {
 	now := time.Now()
 	dueDate := "2024-12-31"
-	reminderTime := "10:00"
+	reminderAt := now.Add(time.Hour)
 
 	todo := Todo{
-		ID:           "test-id",
-		Text:         "Test Todo",
-		Priority:     PriorityHigh,
-		Completed:    false,
-		DueDate:      &dueDate,
-		ReminderTime: &reminderTime,
-		CreatedAt:    now,
-		UpdatedAt:    now,
+		ID:        "test-id",
+		Text:      "Test Todo",
+		Priority:  PriorityHigh,
+		Completed: false,
+		DueDate:   &dueDate,
+		Reminders: []Reminder{{At: &reminderAt}},
+		CreatedAt: now,
+		UpdatedAt: now,
 	}
 
 	// Test all fields
@@ -57,20 +58,20 @@ func TestTodoStructure(t *testing.T) {
 	if *todo.DueDate != dueDate {
 		t.Errorf("DueDate = %v, want %v", *todo.DueDate, dueDate)
 	}
-	if *todo.ReminderTime != reminderTime {
-		t.Errorf("ReminderTime = %v, want %v", *todo.ReminderTime, reminderTime)
+	if len(todo.Reminders) != 1 || !todo.Reminders[0].At.Equal(reminderAt) {
+		t.Errorf("Reminders = %v, want one reminder at %v", todo.Reminders, reminderAt)
 	}
 }
 
 func TestTodoCreateStructure(t *testing.T) {
 	dueDate := "2024-12-31"
-	
+
 	create := TodoCreate{
-		Text:         "New Todo",
-		Priority:     PriorityMedium,
-		Completed:    false,
-		DueDate:      &dueDate,
-		ReminderTime: nil,
+		Text:      "New Todo",
+		Priority:  PriorityMedium,
+		Completed: false,
+		DueDate:   &dueDate,
+		Reminders: nil,
 	}
 
 	if create.Text != "New Todo" {
@@ -84,6 +85,89 @@ func TestTodoCreateStructure(t *testing.T) {
 	}
 }
 
+// TestReminderJSONRoundTripAbsolute checks that an absolute Reminder
+// (At set, RelativeTo empty) survives a JSON round trip unchanged.
+func TestReminderJSONRoundTripAbsolute(t *testing.T) {
+	at := time.Date(2026, 8, 1, 9, 30, 0, 0, time.UTC)
+	reminder := Reminder{At: &at}
+
+	data, err := json.Marshal(reminder)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var got Reminder
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got.At == nil || !got.At.Equal(at) {
+		t.Errorf("At = %v, want %v", got.At, at)
+	}
+	if got.RelativeTo != "" || got.OffsetSeconds != 0 {
+		t.Errorf("expected a bare absolute reminder, got %+v", got)
+	}
+}
+
+// TestReminderJSONRoundTripRelative checks that a relative Reminder (At
+// nil, RelativeTo/OffsetSeconds set) survives a JSON round trip unchanged.
+func TestReminderJSONRoundTripRelative(t *testing.T) {
+	reminder := Reminder{RelativeTo: ReminderAnchorDueDate, OffsetSeconds: -3600}
+
+	data, err := json.Marshal(reminder)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if string(data) != `{"relativeTo":"dueDate","offsetSeconds":-3600}` {
+		t.Errorf("Marshal = %s, want no \"at\" field and both relative fields present", data)
+	}
+
+	var got Reminder
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got.At != nil {
+		t.Errorf("At = %v, want nil", got.At)
+	}
+	if got.RelativeTo != ReminderAnchorDueDate || got.OffsetSeconds != -3600 {
+		t.Errorf("got %+v, want RelativeTo=dueDate OffsetSeconds=-3600", got)
+	}
+}
+
+// TestTodoRemindersJSONRoundTrip checks that Todo.Reminders (a mix of
+// absolute and relative entries) survives a JSON round trip unchanged.
+func TestTodoRemindersJSONRoundTrip(t *testing.T) {
+	at := time.Date(2026, 8, 1, 9, 30, 0, 0, time.UTC)
+	dueDate := "2026-08-01"
+	todo := Todo{
+		ID:      "test-id",
+		Text:    "Multiple reminders",
+		DueDate: &dueDate,
+		Reminders: []Reminder{
+			{At: &at},
+			{RelativeTo: ReminderAnchorDueDate, OffsetSeconds: -3600},
+		},
+	}
+
+	data, err := json.Marshal(todo)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var got Todo
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(got.Reminders) != 2 {
+		t.Fatalf("Reminders = %+v, want 2 entries", got.Reminders)
+	}
+	if got.Reminders[0].At == nil || !got.Reminders[0].At.Equal(at) {
+		t.Errorf("Reminders[0].At = %v, want %v", got.Reminders[0].At, at)
+	}
+	if got.Reminders[1].RelativeTo != ReminderAnchorDueDate || got.Reminders[1].OffsetSeconds != -3600 {
+		t.Errorf("Reminders[1] = %+v, want RelativeTo=dueDate OffsetSeconds=-3600", got.Reminders[1])
+	}
+}
+
 func TestTodoUpdateStructure(t *testing.T) {
 	text := "Updated Text"
 	priority := PriorityLow
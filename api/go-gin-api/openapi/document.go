@@ -0,0 +1,150 @@
+package openapi
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Document is a (partial) OpenAPI 3.0 document: enough to describe this
+// API's request/response shapes and routes for a generated client or
+// Swagger UI, not a full spec implementation.
+type Document struct {
+	OpenAPI    string              `json:"openapi" yaml:"openapi"`
+	Info       Info                `json:"info" yaml:"info"`
+	Paths      map[string]PathItem `json:"paths" yaml:"paths"`
+	Components Components          `json:"components" yaml:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title" yaml:"title"`
+	Version string `json:"version" yaml:"version"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas" yaml:"schemas"`
+}
+
+// PathItem groups a path's operations by HTTP method ("get", "post", ...).
+type PathItem map[string]Operation
+
+type Operation struct {
+	Summary     string              `json:"summary,omitempty" yaml:"summary,omitempty"`
+	OperationID string              `json:"operationId" yaml:"operationId"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses" yaml:"responses"`
+}
+
+type RequestBody struct {
+	Content map[string]MediaType `json:"content" yaml:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description" yaml:"description"`
+	Content     map[string]MediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema SchemaRef `json:"schema" yaml:"schema"`
+}
+
+// SchemaRef points at a named entry under components.schemas.
+type SchemaRef struct {
+	Ref string `json:"$ref" yaml:"$ref"`
+}
+
+func schemaRef(name string) SchemaRef {
+	return SchemaRef{Ref: "#/components/schemas/" + name}
+}
+
+// RouteMeta supplements a gin route with the metadata this package can't
+// infer from gin.RouteInfo alone: a human summary, and which component
+// schema (by name, matching a key in Components.Schemas) its request body
+// and success response use. Either may be "" for a route with no JSON body
+// on that side.
+type RouteMeta struct {
+	Summary      string
+	RequestBody  string
+	ResponseBody string
+}
+
+// Build walks routes (the result of (*gin.Engine).Routes(), i.e. everything
+// routes.SetupRoutes registered) and schemas (built with ReflectSchema) into
+// a Document, filling in each route's request/response bodies from meta
+// where a matching "METHOD /path" entry exists. Routes with no entry still
+// get a path item, just without a typed body.
+func Build(title, version string, routes gin.RoutesInfo, schemas map[string]*Schema, meta map[string]RouteMeta) Document {
+	doc := Document{
+		OpenAPI:    "3.0.3",
+		Info:       Info{Title: title, Version: version},
+		Paths:      map[string]PathItem{},
+		Components: Components{Schemas: schemas},
+	}
+
+	for _, route := range routes {
+		path := ginPathToOpenAPI(route.Path)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = PathItem{}
+			doc.Paths[path] = item
+		}
+
+		m := meta[route.Method+" "+route.Path]
+		op := Operation{
+			Summary:     m.Summary,
+			OperationID: operationID(route.Method, route.Path),
+			Responses: map[string]Response{
+				"200": {Description: "OK"},
+			},
+		}
+		if m.ResponseBody != "" {
+			op.Responses["200"] = Response{
+				Description: "OK",
+				Content: map[string]MediaType{
+					"application/json": {Schema: schemaRef(m.ResponseBody)},
+				},
+			}
+		}
+		if m.RequestBody != "" {
+			op.RequestBody = &RequestBody{
+				Content: map[string]MediaType{
+					"application/json": {Schema: schemaRef(m.RequestBody)},
+				},
+			}
+		}
+
+		item[strings.ToLower(route.Method)] = op
+	}
+
+	return doc
+}
+
+// ginPathToOpenAPI rewrites gin's ":name" path parameters into OpenAPI's
+// "{name}" form, e.g. "/api/todos/:id" -> "/api/todos/{id}".
+func ginPathToOpenAPI(path string) string {
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if len(seg) > 0 && seg[0] == ':' {
+			segments[i] = "{" + seg[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// operationID derives a stable, unique-ish identifier from a route's method
+// and gin path, e.g. "GET /api/todos/:id" -> "get_api_todos_id".
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, r := range path {
+		switch {
+		case r == '/':
+			b.WriteByte('_')
+		case r == ':':
+			// drop the param sigil; the preceding "/" already added a separator
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		}
+	}
+	return strings.Trim(b.String(), "_")
+}
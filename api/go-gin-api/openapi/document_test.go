@@ -0,0 +1,82 @@
+package openapi
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestBuildMapsRouteMetaOntoMatchingRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	noop := func(c *gin.Context) {}
+	router.GET("/api/todos/:id", noop)
+	router.POST("/api/todos", noop)
+
+	schemas := map[string]*Schema{"Todo": {Type: "object"}, "TodoCreate": {Type: "object"}}
+	meta := map[string]RouteMeta{
+		"GET /api/todos/:id": {Summary: "Get a todo by ID", ResponseBody: "Todo"},
+		"POST /api/todos":    {Summary: "Create a todo", RequestBody: "TodoCreate", ResponseBody: "Todo"},
+	}
+
+	doc := Build("Test API", "0.0.1", router.Routes(), schemas, meta)
+
+	item, ok := doc.Paths["/api/todos/{id}"]
+	if !ok {
+		t.Fatal("expected a path item for /api/todos/{id}")
+	}
+	op, ok := item["get"]
+	if !ok {
+		t.Fatal("expected a get operation")
+	}
+	if op.Responses["200"].Content["application/json"].Schema.Ref != "#/components/schemas/Todo" {
+		t.Errorf("expected the get response to ref Todo, got %+v", op.Responses["200"])
+	}
+
+	createItem := doc.Paths["/api/todos"]
+	createOp := createItem["post"]
+	if createOp.RequestBody == nil {
+		t.Fatal("expected the post operation to have a request body")
+	}
+	if createOp.RequestBody.Content["application/json"].Schema.Ref != "#/components/schemas/TodoCreate" {
+		t.Errorf("expected the post request body to ref TodoCreate, got %+v", createOp.RequestBody)
+	}
+}
+
+func TestBuildLeavesUnmappedRoutesUntyped(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/health", func(c *gin.Context) {})
+
+	doc := Build("Test API", "0.0.1", router.Routes(), nil, nil)
+
+	op := doc.Paths["/health"]["get"]
+	if op.RequestBody != nil {
+		t.Error("expected an unmapped route to have no request body")
+	}
+	if op.Responses["200"].Content != nil {
+		t.Error("expected an unmapped route's response to have no typed content")
+	}
+}
+
+func TestWriteYAMLProducesParseableStructure(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/todos/:id", func(c *gin.Context) {})
+
+	doc := Build("Test API", "0.0.1", router.Routes(), NamedSchemas(), RouteTable())
+
+	var buf bytes.Buffer
+	if err := WriteYAML(&buf, doc); err != nil {
+		t.Fatalf("WriteYAML returned an error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"openapi: \"3.0.3\"", "paths:", "\"/api/todos/{id}\":", "components:", "schemas:"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
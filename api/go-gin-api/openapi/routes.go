@@ -0,0 +1,42 @@
+package openapi
+
+import "spicytodo-go-api/models"
+
+// NamedSchemas builds the component schemas this package curates route
+// metadata against. Keep this list and RouteTable in sync: a RouteMeta entry
+// referencing a name that isn't in here is a broken $ref in the generated
+// document.
+func NamedSchemas() map[string]*Schema {
+	return map[string]*Schema{
+		"Todo":          ReflectSchema(models.Todo{}),
+		"TodoCreate":    ReflectSchema(models.TodoCreate{}),
+		"TodoUpdate":    ReflectSchema(models.TodoUpdate{}),
+		"TodoStats":     ReflectSchema(models.TodoStats{}),
+		"BulkOperation": ReflectSchema(models.BulkOperation{}),
+		"ImportRequest": ReflectSchema(models.ImportRequest{}),
+		"ExportResult":  ReflectSchema(models.ExportResult{}),
+		"SnoozeRequest": ReflectSchema(models.SnoozeRequest{}),
+	}
+}
+
+// RouteTable maps "METHOD /gin/path" (matching gin.RouteInfo.Method/.Path
+// exactly, params and all) to the request/response schema it documents.
+// Routes with no entry here still appear in the generated document, just
+// without a typed body.
+func RouteTable() map[string]RouteMeta {
+	return map[string]RouteMeta{
+		"GET /api/todos":                {Summary: "List todos", ResponseBody: "Todo"},
+		"POST /api/todos":               {Summary: "Create a todo", RequestBody: "TodoCreate", ResponseBody: "Todo"},
+		"GET /api/todos/:id":            {Summary: "Get a todo by ID", ResponseBody: "Todo"},
+		"PUT /api/todos/:id":            {Summary: "Update a todo", RequestBody: "TodoUpdate", ResponseBody: "Todo"},
+		"DELETE /api/todos/:id":         {Summary: "Delete a todo"},
+		"PATCH /api/todos/:id/toggle":   {Summary: "Toggle a todo's completed state", ResponseBody: "Todo"},
+		"PATCH /api/todos/:id/snooze":   {Summary: "Snooze a todo's reminder", RequestBody: "SnoozeRequest", ResponseBody: "Todo"},
+		"PATCH /api/todos/:id/unsnooze": {Summary: "Clear a todo's snooze", ResponseBody: "Todo"},
+		"GET /api/todos/stats/summary":  {Summary: "Get todo statistics", ResponseBody: "TodoStats"},
+		"GET /api/todos/reminders":      {Summary: "List upcoming reminders", ResponseBody: "Todo"},
+		"POST /api/todos/bulk":          {Summary: "Apply a bulk operation to todos", RequestBody: "BulkOperation"},
+		"GET /api/export/todos":         {Summary: "Export todos", ResponseBody: "ExportResult"},
+		"POST /api/import/todos":        {Summary: "Import todos", RequestBody: "ImportRequest"},
+	}
+}
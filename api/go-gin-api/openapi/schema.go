@@ -0,0 +1,161 @@
+// Package openapi builds an OpenAPI 3 document describing this API's
+// request/response models and routes, for GET /openapi.json, GET /docs,
+// and the `go generate`-driven api/openapi.yaml (see cmd/genopenapi).
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema is the subset of JSON Schema (as embedded in an OpenAPI document)
+// this package needs: object/array/string/number/integer/boolean types,
+// required properties, and string length bounds.
+type Schema struct {
+	Type       string             `json:"type,omitempty" yaml:"type,omitempty"`
+	Format     string             `json:"format,omitempty" yaml:"format,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Items      *Schema            `json:"items,omitempty" yaml:"items,omitempty"`
+	Required   []string           `json:"required,omitempty" yaml:"required,omitempty"`
+	MinLength  *int               `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	MaxLength  *int               `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	Nullable   bool               `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// ReflectSchema builds a Schema describing v's type by walking its exported
+// struct fields. Property names and omission come from each field's `json`
+// tag; a `binding` tag's `required`, `min=N`, and `max=N` rules become
+// `required`/`minLength`/`maxLength` on the matching property.
+func ReflectSchema(v interface{}) *Schema {
+	return schemaForType(reflect.TypeOf(v))
+}
+
+func schemaForType(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+
+	case t.Kind() == reflect.Struct:
+		return schemaForStruct(t)
+
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		return &Schema{Type: "array", Items: schemaForType(t.Elem())}
+
+	case t.Kind() == reflect.Map:
+		return &Schema{Type: "object"}
+
+	case t.Kind() == reflect.String:
+		return &Schema{Type: "string"}
+
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		return &Schema{Type: "integer"}
+
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return &Schema{Type: "number"}
+
+	case t.Kind() == reflect.Bool:
+		return &Schema{Type: "boolean"}
+
+	case t.Kind() == reflect.Interface:
+		return &Schema{}
+
+	default:
+		return &Schema{Type: "string"}
+	}
+}
+
+func schemaForStruct(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" { // unexported
+			continue
+		}
+
+		name, omitted := jsonFieldName(field)
+		if name == "-" {
+			continue
+		}
+
+		prop := schemaForType(field.Type)
+		if field.Type.Kind() == reflect.Ptr {
+			prop.Nullable = true
+		}
+
+		required, minLen, maxLen := bindingConstraints(field.Tag.Get("binding"))
+		if minLen != nil {
+			prop.MinLength = minLen
+		}
+		if maxLen != nil {
+			prop.MaxLength = maxLen
+		}
+
+		schema.Properties[name] = prop
+		if required && !omitted {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return schema
+}
+
+// jsonFieldName resolves a struct field's property name and whether its
+// `json` tag carries `omitempty`, falling back to the field's own name and
+// not-omitted when there's no tag.
+func jsonFieldName(field reflect.StructField) (name string, omitempty bool) {
+	tag := field.Tag.Get("json")
+	if tag == "" {
+		return field.Name, false
+	}
+
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitempty = true
+		}
+	}
+	return name, omitempty
+}
+
+// bindingConstraints parses a gin `binding` tag value (e.g.
+// "required,min=1,max=500") into the required flag and min/max length
+// bounds ReflectSchema maps onto minLength/maxLength.
+func bindingConstraints(tag string) (required bool, minLen, maxLen *int) {
+	if tag == "" {
+		return false, nil, nil
+	}
+
+	for _, rule := range strings.Split(tag, ",") {
+		key, value, hasValue := strings.Cut(rule, "=")
+		switch key {
+		case "required":
+			required = true
+		case "min":
+			if hasValue {
+				if n, err := strconv.Atoi(value); err == nil {
+					minLen = &n
+				}
+			}
+		case "max":
+			if hasValue {
+				if n, err := strconv.Atoi(value); err == nil {
+					maxLen = &n
+				}
+			}
+		}
+	}
+	return required, minLen, maxLen
+}
@@ -0,0 +1,83 @@
+package openapi
+
+import (
+	"spicytodo-go-api/models"
+	"testing"
+)
+
+func TestReflectSchemaMarksRequiredAndLengthBounds(t *testing.T) {
+	schema := ReflectSchema(models.TodoCreate{})
+
+	if schema.Type != "object" {
+		t.Fatalf("expected an object schema, got %q", schema.Type)
+	}
+
+	text, ok := schema.Properties["text"]
+	if !ok {
+		t.Fatal("expected a text property")
+	}
+	if text.MinLength == nil || *text.MinLength != 1 {
+		t.Errorf("expected text.minLength=1, got %v", text.MinLength)
+	}
+	if text.MaxLength == nil || *text.MaxLength != 500 {
+		t.Errorf("expected text.maxLength=500, got %v", text.MaxLength)
+	}
+
+	found := false
+	for _, name := range schema.Required {
+		if name == "text" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected text to be required, got required=%v", schema.Required)
+	}
+}
+
+func TestReflectSchemaOmitsUnexportedAndDashedFields(t *testing.T) {
+	type hidden struct {
+		Visible string `json:"visible"`
+		Skipped string `json:"-"`
+		private string
+	}
+	_ = hidden{}.private
+
+	schema := ReflectSchema(hidden{})
+
+	if _, ok := schema.Properties["visible"]; !ok {
+		t.Error("expected the visible field to produce a property")
+	}
+	if _, ok := schema.Properties["Skipped"]; ok {
+		t.Error("expected a json:\"-\" field to be omitted")
+	}
+	if _, ok := schema.Properties["private"]; ok {
+		t.Error("expected an unexported field to be omitted")
+	}
+}
+
+func TestReflectSchemaMarksPointerFieldsNullable(t *testing.T) {
+	schema := ReflectSchema(models.TodoUpdate{})
+
+	text, ok := schema.Properties["text"]
+	if !ok {
+		t.Fatal("expected a text property")
+	}
+	if !text.Nullable {
+		t.Error("expected a *string field to be nullable")
+	}
+}
+
+func TestReflectSchemaExpandsSliceItems(t *testing.T) {
+	schema := ReflectSchema(models.TodoCreate{})
+
+	tags, ok := schema.Properties["tags"]
+	if !ok {
+		t.Fatal("expected a tags property")
+	}
+	if tags.Type != "array" {
+		t.Fatalf("expected tags to be an array, got %q", tags.Type)
+	}
+	if tags.Items == nil || tags.Items.Type != "string" {
+		t.Errorf("expected tags.items to be a string schema, got %+v", tags.Items)
+	}
+}
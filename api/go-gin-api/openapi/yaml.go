@@ -0,0 +1,188 @@
+package openapi
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WriteYAML writes doc as YAML to w. There's no YAML dependency pinned in
+// this module, so this walks Document's known shape directly rather than
+// pulling one in for a single generate-time write; it's not a general-purpose
+// YAML encoder.
+func WriteYAML(w io.Writer, doc Document) error {
+	b := &yamlBuilder{w: w}
+	b.line(0, "openapi: %s", yamlString(doc.OpenAPI))
+	b.line(0, "info:")
+	b.line(1, "title: %s", yamlString(doc.Info.Title))
+	b.line(1, "version: %s", yamlString(doc.Info.Version))
+
+	b.line(0, "paths:")
+	for _, path := range sortedKeys(mapKeys(doc.Paths)) {
+		b.line(1, "%s:", yamlKey(path))
+		item := doc.Paths[path]
+		for _, method := range sortedKeys(mapKeysOp(item)) {
+			b.writeOperation(2, method, item[method])
+		}
+	}
+
+	b.line(0, "components:")
+	b.line(1, "schemas:")
+	for _, name := range sortedKeys(mapKeysSchema(doc.Components.Schemas)) {
+		b.line(2, "%s:", yamlKey(name))
+		b.writeSchema(3, doc.Components.Schemas[name])
+	}
+
+	return b.err
+}
+
+func (b *yamlBuilder) writeOperation(indent int, method string, op Operation) {
+	b.line(indent, "%s:", method)
+	if op.Summary != "" {
+		b.line(indent+1, "summary: %s", yamlString(op.Summary))
+	}
+	b.line(indent+1, "operationId: %s", yamlString(op.OperationID))
+	if op.RequestBody != nil {
+		b.line(indent+1, "requestBody:")
+		b.line(indent+2, "content:")
+		b.writeContent(indent+3, op.RequestBody.Content)
+	}
+	b.line(indent+1, "responses:")
+	for _, code := range sortedKeys(mapKeysResponse(op.Responses)) {
+		resp := op.Responses[code]
+		b.line(indent+2, "%s:", yamlKey(code))
+		b.line(indent+3, "description: %s", yamlString(resp.Description))
+		if len(resp.Content) > 0 {
+			b.line(indent+3, "content:")
+			b.writeContent(indent+4, resp.Content)
+		}
+	}
+}
+
+func (b *yamlBuilder) writeContent(indent int, content map[string]MediaType) {
+	for _, mediaType := range sortedKeys(mapKeysMedia(content)) {
+		b.line(indent+1, "%s:", yamlKey(mediaType))
+		b.line(indent+2, "schema:")
+		b.line(indent+3, "$ref: %s", yamlString(content[mediaType].Schema.Ref))
+	}
+}
+
+func (b *yamlBuilder) writeSchema(indent int, s *Schema) {
+	if s == nil {
+		b.line(indent, "{}")
+		return
+	}
+
+	if s.Type != "" {
+		b.line(indent, "type: %s", s.Type)
+	}
+	if s.Format != "" {
+		b.line(indent, "format: %s", s.Format)
+	}
+	if s.Nullable {
+		b.line(indent, "nullable: true")
+	}
+	if s.MinLength != nil {
+		b.line(indent, "minLength: %d", *s.MinLength)
+	}
+	if s.MaxLength != nil {
+		b.line(indent, "maxLength: %d", *s.MaxLength)
+	}
+	if len(s.Required) > 0 {
+		b.line(indent, "required:")
+		for _, name := range s.Required {
+			b.line(indent+1, "- %s", yamlString(name))
+		}
+	}
+	if s.Items != nil {
+		b.line(indent, "items:")
+		b.writeSchema(indent+1, s.Items)
+	}
+	if len(s.Properties) > 0 {
+		b.line(indent, "properties:")
+		for _, name := range sortedKeys(mapKeysSchema(s.Properties)) {
+			b.line(indent+1, "%s:", yamlKey(name))
+			b.writeSchema(indent+2, s.Properties[name])
+		}
+	}
+}
+
+type yamlBuilder struct {
+	w   io.Writer
+	err error
+}
+
+func (b *yamlBuilder) line(indent int, format string, args ...interface{}) {
+	if b.err != nil {
+		return
+	}
+	_, b.err = fmt.Fprintf(b.w, "%s%s\n", strings.Repeat("  ", indent), fmt.Sprintf(format, args...))
+}
+
+// yamlString quotes s as a YAML double-quoted scalar so punctuation in
+// summaries, refs, and path segments can't be misread as YAML syntax.
+func yamlString(s string) string {
+	return strconv.Quote(s)
+}
+
+// yamlKey renders a mapping key unquoted when it's already a bare,
+// unambiguous YAML scalar (an OpenAPI path or HTTP status code), and quoted
+// otherwise, matching how a human-authored spec would look.
+func yamlKey(s string) string {
+	if s == "" {
+		return yamlString(s)
+	}
+	for _, r := range s {
+		if r == ':' || r == '#' || r == '{' || r == '}' || r == '[' || r == ']' || r == ',' || r == '&' || r == '*' || r == '!' || r == '|' || r == '>' || r == '\'' || r == '"' || r == '%' || r == '@' || r == '`' {
+			return yamlString(s)
+		}
+	}
+	return s
+}
+
+func sortedKeys(keys []string) []string {
+	sort.Strings(keys)
+	return keys
+}
+
+func mapKeys(m map[string]PathItem) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func mapKeysOp(m PathItem) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func mapKeysResponse(m map[string]Response) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func mapKeysMedia(m map[string]MediaType) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func mapKeysSchema(m map[string]*Schema) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,503 @@
+// Package recurrence implements the subset of iCalendar (RFC 5545) RRULE
+// recurrence rules needed to expand a todo's due date into its future
+// occurrences: FREQ/INTERVAL/BYMONTH/BYMONTHDAY/BYDAY/BYSETPOS/COUNT/UNTIL/
+// WKST. It has no knowledge of todos; callers own the mapping from their own
+// domain (a due date, a legacy enum) onto an RRULE string and a DTSTART.
+package recurrence
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is an RRULE FREQ value.
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+// ByDay is one BYDAY term. Ordinal is 0 for a plain weekday (matches every
+// occurrence of that weekday in the period, e.g. "MO"), or a 1-based
+// (optionally negative, counting from the end) position within the period,
+// e.g. 2 for "2TU" (second Tuesday) or -1 for "-1FR" (last Friday).
+type ByDay struct {
+	Ordinal int
+	Day     time.Weekday
+}
+
+// Rule is a parsed RRULE. It holds no anchor date of its own: Next and
+// Between take DTSTART (RFC 5545's term for a series' first occurrence) as
+// a parameter, since that's a property of the event/todo, not the rule.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	ByMonth    []int
+	ByMonthDay []int
+	ByDay      []ByDay
+	BySetPos   []int
+	Count      int
+	Until      *time.Time
+	WKST       time.Weekday
+}
+
+// maxPeriods bounds how many FREQ periods Next/Between will scan before
+// giving up, so an unsatisfiable rule (e.g. BYMONTH=2;BYMONTHDAY=31) fails
+// fast instead of looping forever.
+const maxPeriods = 100000
+
+var weekdayNames = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+func weekdayName(wd time.Weekday) string {
+	for name, d := range weekdayNames {
+		if d == wd {
+			return name
+		}
+	}
+	return ""
+}
+
+var byDayRe = regexp.MustCompile(`^([+-]?\d+)?(SU|MO|TU|WE|TH|FR|SA)$`)
+
+// Parse parses an RRULE value string (everything after "RRULE:"), e.g.
+// "FREQ=WEEKLY;BYDAY=MO,WE,FR;INTERVAL=2;UNTIL=20251231T000000Z".
+func Parse(s string) (Rule, error) {
+	rule := Rule{Interval: 1, WKST: time.Monday}
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Rule{}, fmt.Errorf("recurrence: empty RRULE")
+	}
+
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		key = strings.ToUpper(key)
+
+		switch key {
+		case "FREQ":
+			rule.Freq = Frequency(strings.ToUpper(value))
+
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return Rule{}, fmt.Errorf("recurrence: invalid INTERVAL %q", value)
+			}
+			rule.Interval = n
+
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return Rule{}, fmt.Errorf("recurrence: invalid COUNT %q", value)
+			}
+			rule.Count = n
+
+		case "UNTIL":
+			until, err := parseUntil(value)
+			if err != nil {
+				return Rule{}, fmt.Errorf("recurrence: invalid UNTIL %q: %w", value, err)
+			}
+			rule.Until = &until
+
+		case "WKST":
+			wd, ok := weekdayNames[strings.ToUpper(value)]
+			if !ok {
+				return Rule{}, fmt.Errorf("recurrence: invalid WKST %q", value)
+			}
+			rule.WKST = wd
+
+		case "BYMONTH":
+			for _, tok := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(tok)
+				if err != nil || n < 1 || n > 12 {
+					return Rule{}, fmt.Errorf("recurrence: invalid BYMONTH %q", tok)
+				}
+				rule.ByMonth = append(rule.ByMonth, n)
+			}
+
+		case "BYMONTHDAY":
+			for _, tok := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(tok)
+				if err != nil || n == 0 || n < -31 || n > 31 {
+					return Rule{}, fmt.Errorf("recurrence: invalid BYMONTHDAY %q", tok)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, n)
+			}
+
+		case "BYSETPOS":
+			for _, tok := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(tok)
+				if err != nil || n == 0 {
+					return Rule{}, fmt.Errorf("recurrence: invalid BYSETPOS %q", tok)
+				}
+				rule.BySetPos = append(rule.BySetPos, n)
+			}
+
+		case "BYDAY":
+			for _, tok := range strings.Split(value, ",") {
+				bd, err := parseByDay(tok)
+				if err != nil {
+					return Rule{}, err
+				}
+				rule.ByDay = append(rule.ByDay, bd)
+			}
+		}
+	}
+
+	switch rule.Freq {
+	case Daily, Weekly, Monthly, Yearly:
+	default:
+		return Rule{}, fmt.Errorf("recurrence: missing or unsupported FREQ %q", rule.Freq)
+	}
+
+	return rule, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	return time.Parse("20060102", value)
+}
+
+func parseByDay(tok string) (ByDay, error) {
+	m := byDayRe.FindStringSubmatch(strings.ToUpper(tok))
+	if m == nil {
+		return ByDay{}, fmt.Errorf("recurrence: invalid BYDAY %q", tok)
+	}
+	var ordinal int
+	if m[1] != "" {
+		ordinal, _ = strconv.Atoi(m[1])
+	}
+	return ByDay{Ordinal: ordinal, Day: weekdayNames[m[2]]}, nil
+}
+
+// String serializes rule back into RRULE value form, the inverse of Parse.
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByMonth) > 0 {
+		parts = append(parts, "BYMONTH="+joinInts(r.ByMonth))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay))
+	}
+	if len(r.ByDay) > 0 {
+		names := make([]string, len(r.ByDay))
+		for i, bd := range r.ByDay {
+			prefix := ""
+			if bd.Ordinal != 0 {
+				prefix = strconv.Itoa(bd.Ordinal)
+			}
+			names[i] = prefix + weekdayName(bd.Day)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(names, ","))
+	}
+	if len(r.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinInts(r.BySetPos))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	if r.WKST != time.Monday {
+		parts = append(parts, "WKST="+weekdayName(r.WKST))
+	}
+	return strings.Join(parts, ";")
+}
+
+func joinInts(ns []int) string {
+	strs := make([]string, len(ns))
+	for i, n := range ns {
+		strs[i] = strconv.Itoa(n)
+	}
+	return strings.Join(strs, ",")
+}
+
+// Next returns the first occurrence of rule strictly after `after`, anchored
+// at dtstart (the series' DTSTART / first occurrence). It returns false if
+// COUNT or UNTIL is exhausted, or the rule never produces a matching
+// occurrence (e.g. FREQ=MONTHLY;BYMONTHDAY=31;BYMONTH=2), before one past
+// `after` is found.
+func (r Rule) Next(dtstart, after time.Time) (time.Time, bool) {
+	var found time.Time
+	ok := false
+
+	r.walk(dtstart, func(t time.Time) bool {
+		if t.After(after) {
+			found, ok = t, true
+			return false
+		}
+		return true
+	})
+
+	return found, ok
+}
+
+// Between returns every occurrence of rule, anchored at dtstart, that falls
+// within [start, end] inclusive.
+func (r Rule) Between(dtstart, start, end time.Time) []time.Time {
+	var result []time.Time
+
+	r.walk(dtstart, func(t time.Time) bool {
+		if t.After(end) {
+			return false
+		}
+		if !t.Before(start) {
+			result = append(result, t)
+		}
+		return true
+	})
+
+	return result
+}
+
+// walk calls visit, in chronological order, with every occurrence the rule
+// produces starting from dtstart, stopping when visit returns false, COUNT
+// or UNTIL is exhausted, or maxPeriods is reached.
+func (r Rule) walk(dtstart time.Time, visit func(time.Time) bool) {
+	occurrences := 0
+
+	for n := 0; n < maxPeriods; n++ {
+		candidates := r.periodCandidates(dtstart, n)
+		sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+		candidates = applySetPos(candidates, r.BySetPos)
+
+		for _, t := range candidates {
+			occurrences++
+			if r.Count > 0 && occurrences > r.Count {
+				return
+			}
+			if r.Until != nil && t.After(*r.Until) {
+				return
+			}
+			if !visit(t) {
+				return
+			}
+		}
+	}
+}
+
+// periodCandidates generates the n-th period's raw occurrence set (before
+// BYSETPOS is applied), per RFC 5545's BYMONTH -> BYMONTHDAY/BYDAY
+// expansion order. A period with no matching candidates (e.g. BYMONTHDAY=31
+// in February) contributes nothing rather than clamping to a nearby date.
+func (r Rule) periodCandidates(dtstart time.Time, n int) []time.Time {
+	anchor := r.periodAnchor(dtstart, n)
+
+	switch r.Freq {
+	case Daily:
+		if len(r.ByMonth) > 0 && !intIn(int(anchor.Month()), r.ByMonth) {
+			return nil
+		}
+		if len(r.ByDay) > 0 && !byDayMatchesPlain(anchor.Weekday(), r.ByDay) {
+			return nil
+		}
+		return []time.Time{anchor}
+
+	case Weekly:
+		var out []time.Time
+		for d := 0; d < 7; d++ {
+			day := anchor.AddDate(0, 0, d)
+			if len(r.ByMonth) > 0 && !intIn(int(day.Month()), r.ByMonth) {
+				continue
+			}
+			if len(r.ByDay) > 0 {
+				if !byDayMatchesPlain(day.Weekday(), r.ByDay) {
+					continue
+				}
+			} else if day.Weekday() != dtstart.Weekday() {
+				continue
+			}
+			out = append(out, day)
+		}
+		return out
+
+	case Monthly:
+		if len(r.ByMonth) > 0 && !intIn(int(anchor.Month()), r.ByMonth) {
+			return nil
+		}
+		return monthCandidates(anchor, dtstart.Day(), r.ByMonthDay, r.ByDay)
+
+	case Yearly:
+		months := r.ByMonth
+		if len(months) == 0 {
+			months = []int{int(dtstart.Month())}
+		}
+		var out []time.Time
+		for _, month := range months {
+			monthAnchor := time.Date(
+				anchor.Year(), time.Month(month), 1,
+				anchor.Hour(), anchor.Minute(), anchor.Second(), anchor.Nanosecond(), anchor.Location(),
+			)
+			out = append(out, monthCandidates(monthAnchor, dtstart.Day(), r.ByMonthDay, r.ByDay)...)
+		}
+		return out
+	}
+
+	return nil
+}
+
+// periodAnchor returns a reference date for the n-th period of rule's
+// FREQ/INTERVAL, anchored at dtstart: the period's first day for DAILY, the
+// WKST-aligned start of its week for WEEKLY, the first of its month for
+// MONTHLY, and January 1st of its year for YEARLY.
+func (r Rule) periodAnchor(dtstart time.Time, n int) time.Time {
+	switch r.Freq {
+	case Daily:
+		return dtstart.AddDate(0, 0, n*r.Interval)
+	case Weekly:
+		return startOfWeek(dtstart, r.WKST).AddDate(0, 0, 7*n*r.Interval)
+	case Monthly:
+		firstOfMonth := time.Date(
+			dtstart.Year(), dtstart.Month(), 1,
+			dtstart.Hour(), dtstart.Minute(), dtstart.Second(), dtstart.Nanosecond(), dtstart.Location(),
+		)
+		return firstOfMonth.AddDate(0, n*r.Interval, 0)
+	case Yearly:
+		firstOfYear := time.Date(
+			dtstart.Year(), 1, 1,
+			dtstart.Hour(), dtstart.Minute(), dtstart.Second(), dtstart.Nanosecond(), dtstart.Location(),
+		)
+		return firstOfYear.AddDate(n*r.Interval, 0, 0)
+	default:
+		return dtstart
+	}
+}
+
+func startOfWeek(t time.Time, wkst time.Weekday) time.Time {
+	offset := int(t.Weekday()) - int(wkst)
+	if offset < 0 {
+		offset += 7
+	}
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	return midnight.AddDate(0, 0, -offset)
+}
+
+// monthCandidates expands one month's occurrences: explicit BYMONTHDAY
+// values if given (negative counts back from the month's last day), else
+// BYDAY (with RFC 5545 ordinals like "2TU"/"-1FR") if given, else dtstart's
+// own day-of-month. A day that doesn't exist in this month (Feb 30, a 5th
+// Monday that isn't there) is simply omitted.
+func monthCandidates(monthAnchor time.Time, fallbackDay int, byMonthDay []int, byDay []ByDay) []time.Time {
+	daysInMonth := monthAnchor.AddDate(0, 1, -1).Day()
+
+	switch {
+	case len(byMonthDay) > 0:
+		var out []time.Time
+		for _, md := range byMonthDay {
+			day := md
+			if day < 0 {
+				day = daysInMonth + day + 1
+			}
+			if day < 1 || day > daysInMonth {
+				continue
+			}
+			out = append(out, time.Date(
+				monthAnchor.Year(), monthAnchor.Month(), day,
+				monthAnchor.Hour(), monthAnchor.Minute(), monthAnchor.Second(), monthAnchor.Nanosecond(), monthAnchor.Location(),
+			))
+		}
+		return out
+
+	case len(byDay) > 0:
+		var out []time.Time
+		for _, bd := range byDay {
+			out = append(out, monthWeekdayOccurrences(monthAnchor, daysInMonth, bd)...)
+		}
+		return out
+
+	default:
+		if fallbackDay > daysInMonth {
+			return nil
+		}
+		return []time.Time{time.Date(
+			monthAnchor.Year(), monthAnchor.Month(), fallbackDay,
+			monthAnchor.Hour(), monthAnchor.Minute(), monthAnchor.Second(), monthAnchor.Nanosecond(), monthAnchor.Location(),
+		)}
+	}
+}
+
+// monthWeekdayOccurrences returns every day in monthAnchor's month matching
+// bd.Day, narrowed to the bd.Ordinal-th one (from the end, if negative) when
+// an ordinal is given.
+func monthWeekdayOccurrences(monthAnchor time.Time, daysInMonth int, bd ByDay) []time.Time {
+	var matches []time.Time
+	for day := 1; day <= daysInMonth; day++ {
+		d := time.Date(
+			monthAnchor.Year(), monthAnchor.Month(), day,
+			monthAnchor.Hour(), monthAnchor.Minute(), monthAnchor.Second(), monthAnchor.Nanosecond(), monthAnchor.Location(),
+		)
+		if d.Weekday() == bd.Day {
+			matches = append(matches, d)
+		}
+	}
+
+	if bd.Ordinal == 0 {
+		return matches
+	}
+
+	idx := bd.Ordinal - 1
+	if bd.Ordinal < 0 {
+		idx = len(matches) + bd.Ordinal
+	}
+	if idx < 0 || idx >= len(matches) {
+		return nil
+	}
+	return []time.Time{matches[idx]}
+}
+
+func byDayMatchesPlain(wd time.Weekday, days []ByDay) bool {
+	for _, bd := range days {
+		if bd.Day == wd {
+			return true
+		}
+	}
+	return false
+}
+
+func intIn(n int, set []int) bool {
+	for _, s := range set {
+		if s == n {
+			return true
+		}
+	}
+	return false
+}
+
+// applySetPos narrows a period's sorted candidate set to the positions
+// listed in setPos (1-based; negative counts back from the end), per RFC
+// 5545 BYSETPOS. An empty setPos leaves candidates untouched.
+func applySetPos(candidates []time.Time, setPos []int) []time.Time {
+	if len(setPos) == 0 {
+		return candidates
+	}
+
+	var out []time.Time
+	for _, pos := range setPos {
+		idx := pos - 1
+		if pos < 0 {
+			idx = len(candidates) + pos
+		}
+		if idx >= 0 && idx < len(candidates) {
+			out = append(out, candidates[idx])
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Before(out[j]) })
+	return out
+}
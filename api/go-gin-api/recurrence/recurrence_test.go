@@ -0,0 +1,194 @@
+package recurrence
+
+import (
+	"testing"
+	"time"
+)
+
+func mustParse(t *testing.T, s string) Rule {
+	t.Helper()
+	rule, err := Parse(s)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", s, err)
+	}
+	return rule
+}
+
+func TestParseRejectsMissingFreq(t *testing.T) {
+	if _, err := Parse("INTERVAL=2"); err == nil {
+		t.Error("expected an error for a rule with no FREQ")
+	}
+}
+
+func TestParseBYDAYOrdinals(t *testing.T) {
+	rule := mustParse(t, "FREQ=MONTHLY;BYDAY=2TU,-1FR")
+	if len(rule.ByDay) != 2 {
+		t.Fatalf("expected 2 BYDAY entries, got %d", len(rule.ByDay))
+	}
+	if rule.ByDay[0].Ordinal != 2 || rule.ByDay[0].Day != time.Tuesday {
+		t.Errorf("got %+v, want {2 Tuesday}", rule.ByDay[0])
+	}
+	if rule.ByDay[1].Ordinal != -1 || rule.ByDay[1].Day != time.Friday {
+		t.Errorf("got %+v, want {-1 Friday}", rule.ByDay[1])
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	original := "FREQ=MONTHLY;INTERVAL=2;BYMONTHDAY=-1;COUNT=6"
+	rule := mustParse(t, original)
+
+	reparsed := mustParse(t, rule.String())
+	if reparsed.Freq != rule.Freq || reparsed.Interval != rule.Interval || reparsed.Count != rule.Count {
+		t.Errorf("round trip mismatch: got %+v, want %+v", reparsed, rule)
+	}
+	if len(reparsed.ByMonthDay) != 1 || reparsed.ByMonthDay[0] != -1 {
+		t.Errorf("round trip lost BYMONTHDAY: got %+v", reparsed.ByMonthDay)
+	}
+}
+
+func TestNextDaily(t *testing.T) {
+	rule := mustParse(t, "FREQ=DAILY;INTERVAL=3")
+	dtstart := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	next, ok := rule.Next(dtstart, dtstart)
+	if !ok {
+		t.Fatal("expected an occurrence")
+	}
+	if want := dtstart.AddDate(0, 0, 3); !next.Equal(want) {
+		t.Errorf("next = %v, want %v", next, want)
+	}
+}
+
+func TestNextStopsAtCount(t *testing.T) {
+	rule := mustParse(t, "FREQ=DAILY;COUNT=2")
+	dtstart := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	second, ok := rule.Next(dtstart, dtstart)
+	if !ok {
+		t.Fatal("expected a second occurrence")
+	}
+	if _, ok := rule.Next(dtstart, second); ok {
+		t.Error("expected no third occurrence once COUNT=2 is exhausted")
+	}
+}
+
+func TestNextStopsAtUntil(t *testing.T) {
+	until := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	rule, err := Parse("FREQ=DAILY;UNTIL=" + until.Format("20060102T150405Z"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dtstart := time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC)
+
+	next, ok := rule.Next(dtstart, dtstart)
+	if !ok || !next.Equal(until) {
+		t.Fatalf("next = %v, %v; want %v, true", next, ok, until)
+	}
+	if _, ok := rule.Next(dtstart, next); ok {
+		t.Error("expected no occurrence past UNTIL")
+	}
+}
+
+func TestNextWeeklyByDay(t *testing.T) {
+	rule := mustParse(t, "FREQ=WEEKLY;BYDAY=MO,FR")
+	monday := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	next, ok := rule.Next(monday, monday)
+	if !ok || next.Weekday() != time.Friday {
+		t.Fatalf("next = %v, %v; want a Friday", next, ok)
+	}
+
+	next2, ok := rule.Next(monday, next)
+	if !ok || next2.Weekday() != time.Monday {
+		t.Fatalf("next2 = %v, %v; want a Monday", next2, ok)
+	}
+}
+
+func TestNextMonthlyByMonthDaySkipsShortMonths(t *testing.T) {
+	rule := mustParse(t, "FREQ=MONTHLY;BYMONTHDAY=31")
+	jan31 := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	next, ok := rule.Next(jan31, jan31)
+	if !ok {
+		t.Fatal("expected an occurrence")
+	}
+	// February and April (2026) have no 31st, so the next one lands in March.
+	if next.Month() != time.March || next.Day() != 31 {
+		t.Errorf("got %v, want March 31", next)
+	}
+}
+
+func TestNextMonthlyByMonthDayNegative(t *testing.T) {
+	rule := mustParse(t, "FREQ=MONTHLY;BYMONTHDAY=-1")
+	jan15 := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	// The first matching candidate after Jan 15 is still within January.
+	first, ok := rule.Next(jan15, jan15)
+	if !ok || first.Month() != time.January || first.Day() != 31 {
+		t.Fatalf("got %v, %v; want Jan 31 2026", first, ok)
+	}
+
+	second, ok := rule.Next(jan15, first)
+	if !ok || second.Month() != time.February || second.Day() != 28 {
+		t.Errorf("got %v, %v; want Feb 28 2026 (not a leap year)", second, ok)
+	}
+}
+
+func TestNextMonthlyByDayOrdinal(t *testing.T) {
+	// "last Friday of the month"
+	rule := mustParse(t, "FREQ=MONTHLY;BYDAY=-1FR")
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	next, ok := rule.Next(start, start)
+	if !ok || next.Weekday() != time.Friday || next.Month() != time.January || next.Day() != 30 {
+		t.Errorf("got %v, %v; want Friday Jan 30 2026", next, ok)
+	}
+}
+
+func TestNextYearlyByMonth(t *testing.T) {
+	rule := mustParse(t, "FREQ=YEARLY;BYMONTH=3,9;BYMONTHDAY=1")
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	first, ok := rule.Next(start, start)
+	if !ok || first.Month() != time.March || first.Day() != 1 {
+		t.Fatalf("got %v, %v; want March 1", first, ok)
+	}
+
+	second, ok := rule.Next(start, first)
+	if !ok || second.Month() != time.September || second.Day() != 1 {
+		t.Fatalf("got %v, %v; want September 1", second, ok)
+	}
+}
+
+func TestNextMonthlyBySetPos(t *testing.T) {
+	// Second-to-last weekday of the month.
+	rule := mustParse(t, "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-2")
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	next, ok := rule.Next(start, start)
+	if !ok {
+		t.Fatal("expected an occurrence")
+	}
+	if next.Month() != time.January || next.Day() != 29 {
+		t.Errorf("got %v, want Jan 29 2026 (second-to-last weekday)", next)
+	}
+}
+
+func TestNextUnsatisfiableRuleFails(t *testing.T) {
+	rule := mustParse(t, "FREQ=MONTHLY;BYMONTH=2;BYMONTHDAY=31")
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, ok := rule.Next(start, start); ok {
+		t.Error("expected no occurrence for an impossible BYMONTHDAY/BYMONTH combination")
+	}
+}
+
+func TestBetweenReturnsAllOccurrencesInRange(t *testing.T) {
+	rule := mustParse(t, "FREQ=DAILY")
+	dtstart := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	occurrences := rule.Between(dtstart, dtstart, dtstart.AddDate(0, 0, 4))
+	if len(occurrences) != 5 {
+		t.Fatalf("expected 5 occurrences, got %d: %v", len(occurrences), occurrences)
+	}
+}
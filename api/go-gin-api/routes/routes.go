@@ -1,14 +1,29 @@
 package routes
 
 import (
+	"expvar"
+	"net/http"
 	"spicytodo-go-api/handlers"
+	"spicytodo-go-api/middleware"
+	"spicytodo-go-api/middleware/ratelimit"
+	"spicytodo-go-api/openapi"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRoutes(router *gin.Engine, handler *handlers.TodoHandler) {
+// bulkRateLimitPolicy and readRateLimitPolicy are the default token-bucket
+// policies for write-heavy and read-heavy routes, respectively, before any
+// RATE_LIMIT_* env var overrides are applied.
+var (
+	bulkRateLimitPolicy = ratelimit.Policy{Capacity: 10, RefillRate: 5.0 / 60}
+	readRateLimitPolicy = ratelimit.Policy{Capacity: 120, RefillRate: 60}
+)
+
+// SetupRoutes wires up the Gin engine's routes. It returns the rate
+// limiters it creates so main can run their background Sweep loops.
+func SetupRoutes(router *gin.Engine, handler *handlers.TodoHandler) []*ratelimit.Limiter {
 	// CORS configuration
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000", "http://127.0.0.1:3000"},
@@ -23,38 +38,161 @@ func SetupRoutes(router *gin.Engine, handler *handlers.TodoHandler) {
 	router.GET("/", handler.GetRoot)
 	router.GET("/health", handler.GetHealth)
 
+	bulkLimiter := ratelimit.NewLimiter(
+		ratelimit.PolicyFromEnv("RATE_LIMIT_BULK_CAPACITY", "RATE_LIMIT_BULK_REFILL", bulkRateLimitPolicy),
+		nil,
+	)
+	readLimiter := ratelimit.NewLimiter(
+		ratelimit.PolicyFromEnv("RATE_LIMIT_READ_CAPACITY", "RATE_LIMIT_READ_REFILL", readRateLimitPolicy),
+		nil,
+	)
+
 	// API routes
 	api := router.Group("/api")
 	{
 		// Core CRUD operations
-		api.GET("/todos", handler.GetTodos)
-		api.POST("/todos", handler.CreateTodo)
-		api.GET("/todos/:id", handler.GetTodoByID)
-		api.PUT("/todos/:id", handler.UpdateTodo)
-		api.DELETE("/todos/:id", handler.DeleteTodo)
-		api.PATCH("/todos/:id/toggle", handler.ToggleTodo)
-		
+		api.GET("/todos", readLimiter.Middleware(), handlers.Wrap(handler.GetTodos))
+		api.POST("/todos", handlers.Wrap(handler.CreateTodo))
+		api.GET("/todos/:id", handlers.Wrap(handler.GetTodoByID))
+		api.PUT("/todos/:id", handlers.Wrap(handler.UpdateTodo))
+		api.DELETE("/todos/:id", handlers.Wrap(handler.DeleteTodo))
+		api.PATCH("/todos/:id/toggle", handlers.Wrap(handler.ToggleTodo))
+
 		// Snooze operations
-		api.PATCH("/todos/:id/snooze", handler.SnoozeTodo)
-		api.PATCH("/todos/:id/unsnooze", handler.UnsnoozeTodo)
-		
+		api.PATCH("/todos/:id/snooze", handlers.Wrap(handler.SnoozeTodo))
+		api.PATCH("/todos/:id/unsnooze", handlers.Wrap(handler.UnsnoozeTodo))
+
+		// Recurrence operations
+		api.POST("/todos/:id/recurrence", handlers.Wrap(handler.SetTodoRecurrence))
+		api.DELETE("/todos/:id/recurrence", handlers.Wrap(handler.ClearTodoRecurrence))
+
+		// Label-based assignment to a registered agent
+		api.POST("/todos/:id/assign", handlers.Wrap(handler.AssignTodo))
+
 		// Statistics and reminders
-		api.GET("/todos/stats/summary", handler.GetStats)
-		api.GET("/todos/reminders", handler.GetUpcomingReminders)
-		
+		api.GET("/todos/stats/summary", handlers.Wrap(handler.GetStats))
+		api.GET("/todos/reminders", handlers.Wrap(handler.GetUpcomingReminders))
+
+		// Virtual (non-persisted) recurrence expansion within a time window
+		api.GET("/todos/:id/occurrences", handlers.Wrap(handler.GetTodoOccurrences))
+
 		// Tag and category operations
-		api.GET("/todos/tags/:tag", handler.GetByTag)
-		api.GET("/todos/category/:category", handler.GetByCategory)
-		api.GET("/todos/tags", handler.GetAllTags)
-		api.GET("/todos/categories", handler.GetAllCategories)
-		
-		// Bulk operations
-		api.POST("/todos/bulk", handler.BulkOperation)
-		api.DELETE("/todos/completed", handler.ClearCompleted)
-		
+		api.GET("/todos/tags/:tag", handlers.Wrap(handler.GetByTag))
+		api.GET("/todos/category/:category", handlers.Wrap(handler.GetByCategory))
+		api.GET("/todos/tags", handlers.Wrap(handler.GetAllTags))
+		api.GET("/todos/categories", handlers.Wrap(handler.GetAllCategories))
+
+		// Full-text search over the inverted index
+		api.GET("/todos/search", readLimiter.Middleware(), handlers.Wrap(handler.SearchTodos))
+
+		// Bulk operations. These can run long against a large ID list, so they
+		// get a request deadline to bound how long a disconnected or stalled
+		// client can hold server-side work open.
+		api.POST("/todos/bulk", middleware.RequestDeadline(middleware.DefaultRequestDeadline), bulkLimiter.Middleware(), handlers.Wrap(handler.BulkOperation))
+		api.DELETE("/todos/completed", handlers.Wrap(handler.ClearCompleted))
+
 		// Import/Export
 		api.GET("/export/todos", handler.ExportTodos)
-		api.POST("/import/todos", handler.ImportTodos)
+		api.POST("/import/todos", middleware.RequestDeadline(middleware.DefaultRequestDeadline), bulkLimiter.Middleware(), handlers.Wrap(handler.ImportTodos))
+
+		// Event log
+		api.GET("/events", handlers.Wrap(handler.GetEvents))
+		api.GET("/todos/:id/history", handlers.Wrap(handler.GetTodoHistory))
+		api.POST("/todos/:id/undo", handlers.Wrap(handler.UndoTodo))
+
+		// Real-time updates
+		api.GET("/todos/stream", handler.StreamTodos)
+		api.GET("/reminders/stream", handler.StreamReminders)
+		api.GET("/todos/reminders/stream", handler.StreamReminderActivity)
+
+		// Scheduled job queue (reminder delivery, recurrence materialization)
+		api.GET("/jobs", handlers.Wrap(handler.GetJobs))
+		api.DELETE("/jobs/:id", handlers.Wrap(handler.DeleteJob))
+	}
+
+	// Background job queue inspection: pending/dead-letter counts by type,
+	// a single task's status, and retrying one that's been dead-lettered.
+	worker := router.Group("/api/worker")
+	{
+		worker.GET("/queues", handlers.Wrap(handler.GetWorkerQueues))
+		worker.GET("/tasks/:id", handlers.Wrap(handler.GetWorkerTask))
+		worker.POST("/tasks/:id/retry", handlers.Wrap(handler.RetryWorkerTask))
 	}
+
+	// Per-tag topic subscriptions, an alternative to /api/todos/stream?tag=
+	// for clients that want a tag baked into the URL.
+	topics := router.Group("/api/topics")
+	{
+		topics.GET("/:tag", handler.StreamTopic)
+	}
+
+	// Admin operations
+	admin := router.Group("/api/admin")
+	{
+		admin.POST("/replay", handlers.Wrap(handler.ReplayEvents))
+		admin.GET("/scheduler", handlers.Wrap(handler.GetSchedulerPending))
+	}
+
+	// CalDAV sync: pull reconciles the local store from the configured
+	// server by UID, push backfills the server from local state. Both are
+	// no-ops (400) unless CALDAV_URL is set; the server is otherwise also
+	// kept up to date automatically as todos are created/updated/deleted.
+	sync := router.Group("/api/sync/caldav")
+	{
+		sync.POST("/pull", handlers.Wrap(handler.PullCalDAV))
+		sync.POST("/push", handlers.Wrap(handler.PushCalDAV))
+		sync.POST("/sync", handlers.Wrap(handler.SyncCalDAV))
+	}
+
+	// Planned maintenance windows that suppress reminders and defer
+	// recurrence materialization for their targeted todos.
+	maintenance := router.Group("/api/maintenance")
+	{
+		maintenance.GET("", handlers.Wrap(handler.GetMaintenanceWindows))
+		maintenance.GET("/active", handlers.Wrap(handler.GetActiveMaintenanceWindows))
+		maintenance.POST("", handlers.Wrap(handler.CreateMaintenanceWindow))
+		maintenance.PUT("/:id", handlers.Wrap(handler.UpdateMaintenanceWindow))
+		maintenance.DELETE("/:id", handlers.Wrap(handler.DeleteMaintenanceWindow))
+	}
+
+	// Agents that todos can be assigned to via POST /api/todos/:id/assign.
+	agents := router.Group("/api/agents")
+	{
+		agents.GET("", handlers.Wrap(handler.GetAgents))
+		agents.POST("", handlers.Wrap(handler.CreateAgent))
+	}
+
+	// Request metrics (duration/status/bytes per route) recorded by
+	// handlers.Wrap, exposed alongside Go's own runtime vars.
+	router.GET("/debug/vars", gin.WrapH(expvar.Handler()))
+
+	// API documentation. Registered last so router.Routes() below reflects
+	// every route above it; doc is built once at startup, not per-request.
+	doc := openapi.Build("Spicy Todo API", "1.0.0", router.Routes(), openapi.NamedSchemas(), openapi.RouteTable())
+	router.GET("/openapi.json", func(c *gin.Context) {
+		c.JSON(http.StatusOK, doc)
+	})
+	router.GET("/docs", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUIPage))
+	})
+
+	return []*ratelimit.Limiter{bulkLimiter, readLimiter}
 }
 
+// swaggerUIPage is a minimal Swagger UI shell loading /openapi.json from the
+// CDN-hosted swagger-ui-dist bundle, so /docs needs no bundled assets.
+const swaggerUIPage = `<!DOCTYPE html>
+<html>
+<head>
+  <title>Spicy Todo API docs</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = () => SwaggerUIBundle({url: '/openapi.json', dom_id: '#swagger-ui'})
+  </script>
+</body>
+</html>
+`
@@ -0,0 +1,107 @@
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"spicytodo-go-api/models"
+	"sync"
+	"time"
+)
+
+// MemoryQueue is the default Queue: a map guarded by its own mutex, safe to
+// use from tests without Redis running.
+type MemoryQueue struct {
+	mu   sync.Mutex
+	jobs map[string]Job
+	dead map[string]Job
+}
+
+func NewMemoryQueue() *MemoryQueue {
+	return &MemoryQueue{jobs: make(map[string]Job), dead: make(map[string]Job)}
+}
+
+func (q *MemoryQueue) Enqueue(ctx context.Context, job Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.jobs[job.ID] = job
+	return nil
+}
+
+func (q *MemoryQueue) Cancel(ctx context.Context, id string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.jobs, id)
+	return nil
+}
+
+func (q *MemoryQueue) Due(ctx context.Context, now time.Time, limit int) ([]Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	due := make([]Job, 0)
+	for _, job := range q.jobs {
+		if !job.FireAt.After(now) {
+			due = append(due, job)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].FireAt.Before(due[j].FireAt) })
+
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	for _, job := range due {
+		delete(q.jobs, job.ID)
+	}
+	return due, nil
+}
+
+func (q *MemoryQueue) List(ctx context.Context) ([]Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	jobs := make([]Job, 0, len(q.jobs))
+	for _, job := range q.jobs {
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].FireAt.Before(jobs[j].FireAt) })
+	return jobs, nil
+}
+
+func (q *MemoryQueue) MoveToDeadLetter(ctx context.Context, job Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	delete(q.jobs, job.ID)
+	job.Status = models.JobStatusDeadLetter
+	q.dead[job.ID] = job
+	return nil
+}
+
+func (q *MemoryQueue) DeadLetter(ctx context.Context) ([]Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	jobs := make([]Job, 0, len(q.dead))
+	for _, job := range q.dead {
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].FireAt.Before(jobs[j].FireAt) })
+	return jobs, nil
+}
+
+func (q *MemoryQueue) Requeue(ctx context.Context, id string) (Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job, ok := q.dead[id]
+	if !ok {
+		return Job{}, ErrJobNotFound
+	}
+	delete(q.dead, id)
+	job.Status = models.JobStatusPending
+	job.RetryCount = 0
+	job.LastError = ""
+	job.FireAt = time.Now()
+	q.jobs[job.ID] = job
+	return job, nil
+}
@@ -0,0 +1,166 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryQueueDueReturnsOnlyPastJobs(t *testing.T) {
+	q := NewMemoryQueue()
+	ctx := context.Background()
+	now := time.Now()
+
+	q.Enqueue(ctx, Job{ID: "a", Type: JobReminderFire, FireAt: now.Add(-time.Minute)})
+	q.Enqueue(ctx, Job{ID: "b", Type: JobReminderFire, FireAt: now.Add(time.Hour)})
+
+	due, err := q.Due(ctx, now, 10)
+	if err != nil {
+		t.Fatalf("Due: %v", err)
+	}
+	if len(due) != 1 || due[0].ID != "a" {
+		t.Fatalf("Due = %+v, want just job a", due)
+	}
+
+	remaining, _ := q.List(ctx)
+	if len(remaining) != 1 || remaining[0].ID != "b" {
+		t.Errorf("List = %+v, want just job b still pending", remaining)
+	}
+}
+
+func TestMemoryQueueDueClaimsExactlyOnce(t *testing.T) {
+	q := NewMemoryQueue()
+	ctx := context.Background()
+	now := time.Now()
+
+	q.Enqueue(ctx, Job{ID: "a", Type: JobReminderFire, FireAt: now.Add(-time.Minute)})
+
+	first, _ := q.Due(ctx, now, 10)
+	second, _ := q.Due(ctx, now, 10)
+
+	if len(first) != 1 {
+		t.Fatalf("expected the first Due call to claim the job, got %d", len(first))
+	}
+	if len(second) != 0 {
+		t.Errorf("expected the second Due call to claim nothing, got %d", len(second))
+	}
+}
+
+func TestMemoryQueueEnqueueReplacesExistingJob(t *testing.T) {
+	q := NewMemoryQueue()
+	ctx := context.Background()
+	now := time.Now()
+
+	q.Enqueue(ctx, Job{ID: "a", Type: JobReminderFire, FireAt: now.Add(time.Hour)})
+	q.Enqueue(ctx, Job{ID: "a", Type: JobReminderFire, FireAt: now.Add(30 * time.Minute)})
+
+	jobs, _ := q.List(ctx)
+	if len(jobs) != 1 {
+		t.Fatalf("expected re-enqueuing the same ID to replace it, got %d jobs", len(jobs))
+	}
+}
+
+func TestMemoryQueueCancelRemovesPendingJob(t *testing.T) {
+	q := NewMemoryQueue()
+	ctx := context.Background()
+
+	q.Enqueue(ctx, Job{ID: "a", Type: JobRecurrenceCreateNext, FireAt: time.Now().Add(time.Hour)})
+	q.Cancel(ctx, "a")
+
+	jobs, _ := q.List(ctx)
+	if len(jobs) != 0 {
+		t.Errorf("expected Cancel to remove the job, got %+v", jobs)
+	}
+}
+
+func TestMemoryQueueListOrdersByFireTime(t *testing.T) {
+	q := NewMemoryQueue()
+	ctx := context.Background()
+	now := time.Now()
+
+	q.Enqueue(ctx, Job{ID: "later", FireAt: now.Add(2 * time.Hour)})
+	q.Enqueue(ctx, Job{ID: "sooner", FireAt: now.Add(time.Hour)})
+
+	jobs, _ := q.List(ctx)
+	if len(jobs) != 2 || jobs[0].ID != "sooner" {
+		t.Fatalf("List = %+v, want sooner first", jobs)
+	}
+}
+
+func TestBackoffCapsAtOneMinute(t *testing.T) {
+	cases := []struct {
+		retryCount int
+		want       time.Duration
+	}{
+		{0, time.Second},
+		{1, 2 * time.Second},
+		{2, 4 * time.Second},
+		{10, time.Minute},
+	}
+	for _, c := range cases {
+		if got := Backoff(c.retryCount); got != c.want {
+			t.Errorf("Backoff(%d) = %v, want %v", c.retryCount, got, c.want)
+		}
+	}
+}
+
+func TestMemoryQueueMoveToDeadLetterRemovesFromPending(t *testing.T) {
+	q := NewMemoryQueue()
+	ctx := context.Background()
+
+	job := Job{ID: "a", Type: JobCalDAVPush, FireAt: time.Now().Add(-time.Minute), RetryCount: 3}
+	q.Enqueue(ctx, job)
+	due, _ := q.Due(ctx, time.Now(), 10)
+
+	if err := q.MoveToDeadLetter(ctx, due[0]); err != nil {
+		t.Fatalf("MoveToDeadLetter: %v", err)
+	}
+
+	pending, _ := q.List(ctx)
+	if len(pending) != 0 {
+		t.Errorf("expected no pending jobs after MoveToDeadLetter, got %+v", pending)
+	}
+
+	dead, err := q.DeadLetter(ctx)
+	if err != nil {
+		t.Fatalf("DeadLetter: %v", err)
+	}
+	if len(dead) != 1 || dead[0].ID != "a" {
+		t.Fatalf("DeadLetter = %+v, want just job a", dead)
+	}
+}
+
+func TestMemoryQueueRequeueResetsRetryCount(t *testing.T) {
+	q := NewMemoryQueue()
+	ctx := context.Background()
+
+	job := Job{ID: "a", Type: JobCalDAVPush, FireAt: time.Now().Add(-time.Minute), RetryCount: 3, LastError: "boom"}
+	q.Enqueue(ctx, job)
+	due, _ := q.Due(ctx, time.Now(), 10)
+	q.MoveToDeadLetter(ctx, due[0])
+
+	requeued, err := q.Requeue(ctx, "a")
+	if err != nil {
+		t.Fatalf("Requeue: %v", err)
+	}
+	if requeued.RetryCount != 0 || requeued.LastError != "" {
+		t.Errorf("Requeue should reset RetryCount/LastError, got %+v", requeued)
+	}
+
+	dead, _ := q.DeadLetter(ctx)
+	if len(dead) != 0 {
+		t.Errorf("expected Requeue to remove the job from the dead letter list, got %+v", dead)
+	}
+	pending, _ := q.List(ctx)
+	if len(pending) != 1 || pending[0].ID != "a" {
+		t.Fatalf("expected Requeue to put the job back in pending, got %+v", pending)
+	}
+}
+
+func TestMemoryQueueRequeueUnknownIDReturnsErrJobNotFound(t *testing.T) {
+	q := NewMemoryQueue()
+
+	if _, err := q.Requeue(context.Background(), "missing"); err != ErrJobNotFound {
+		t.Errorf("Requeue(missing) error = %v, want ErrJobNotFound", err)
+	}
+}
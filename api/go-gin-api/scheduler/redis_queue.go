@@ -0,0 +1,189 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"spicytodo-go-api/models"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// jobQueueKey is the sorted set scored by each job's FireAt (unix nanos),
+// mirroring how redisStore's "reminders" set is scored by fire time.
+const jobQueueKey = "scheduler:jobs"
+
+// jobKeyPrefix namespaces each job's JSON payload; the rest of the key is
+// its ID.
+const jobKeyPrefix = "scheduler:job:"
+
+// deadLetterKey is a hash of id -> JSON payload for jobs that exhausted
+// their retries, separate from jobQueueKey so GET /api/jobs and
+// GET /api/worker/tasks don't need to filter one list by status.
+const deadLetterKey = "scheduler:deadletter"
+
+// dueScript atomically claims every job due at or before ARGV[1] (up to
+// ARGV[2] of them) and removes them from the queue in the same round trip,
+// so two API instances polling the same queue never both claim one job.
+var dueScript = redis.NewScript(`
+local ids = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+if #ids > 0 then
+	redis.call('ZREM', KEYS[1], unpack(ids))
+end
+return ids
+`)
+
+// RedisQueue backs Queue with Redis, so a shared set of scheduled jobs can
+// be claimed by a pool of worker processes rather than just one.
+type RedisQueue struct {
+	client *redis.Client
+}
+
+func NewRedisQueue(addr string) *RedisQueue {
+	return &RedisQueue{client: redis.NewClient(&redis.Options{Addr: addr})}
+}
+
+func (q *RedisQueue) Enqueue(ctx context.Context, job Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	pipe := q.client.TxPipeline()
+	pipe.Set(ctx, jobKeyPrefix+job.ID, data, 0)
+	pipe.ZAdd(ctx, jobQueueKey, redis.Z{Score: float64(job.FireAt.UnixNano()), Member: job.ID})
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (q *RedisQueue) Cancel(ctx context.Context, id string) error {
+	pipe := q.client.TxPipeline()
+	pipe.ZRem(ctx, jobQueueKey, id)
+	pipe.Del(ctx, jobKeyPrefix+id)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (q *RedisQueue) Due(ctx context.Context, now time.Time, limit int) ([]Job, error) {
+	res, err := dueScript.Run(ctx, q.client, []string{jobQueueKey}, now.UnixNano(), limit).StringSlice()
+	if err != nil || len(res) == 0 {
+		return nil, err
+	}
+	return q.fetchAndForget(ctx, res)
+}
+
+// fetchAndForget loads ids' job payloads and deletes them, since Due has
+// already removed them from the queue's sorted set.
+func (q *RedisQueue) fetchAndForget(ctx context.Context, ids []string) ([]Job, error) {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = jobKeyPrefix + id
+	}
+
+	payloads, err := q.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]Job, 0, len(payloads))
+	for _, payload := range payloads {
+		raw, ok := payload.(string)
+		if !ok {
+			continue
+		}
+		var job Job
+		if err := json.Unmarshal([]byte(raw), &job); err == nil {
+			jobs = append(jobs, job)
+		}
+	}
+
+	q.client.Del(ctx, keys...)
+	return jobs, nil
+}
+
+func (q *RedisQueue) List(ctx context.Context) ([]Job, error) {
+	ids, err := q.client.ZRangeByScore(ctx, jobQueueKey, &redis.ZRangeBy{Min: "-inf", Max: "+inf"}).Result()
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = jobKeyPrefix + id
+	}
+
+	payloads, err := q.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]Job, 0, len(payloads))
+	for _, payload := range payloads {
+		raw, ok := payload.(string)
+		if !ok {
+			continue
+		}
+		var job Job
+		if err := json.Unmarshal([]byte(raw), &job); err == nil {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
+func (q *RedisQueue) MoveToDeadLetter(ctx context.Context, job Job) error {
+	job.Status = models.JobStatusDeadLetter
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	pipe := q.client.TxPipeline()
+	pipe.ZRem(ctx, jobQueueKey, job.ID)
+	pipe.Del(ctx, jobKeyPrefix+job.ID)
+	pipe.HSet(ctx, deadLetterKey, job.ID, data)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (q *RedisQueue) DeadLetter(ctx context.Context) ([]Job, error) {
+	payloads, err := q.client.HGetAll(ctx, deadLetterKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]Job, 0, len(payloads))
+	for _, raw := range payloads {
+		var job Job
+		if err := json.Unmarshal([]byte(raw), &job); err == nil {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
+func (q *RedisQueue) Requeue(ctx context.Context, id string) (Job, error) {
+	raw, err := q.client.HGet(ctx, deadLetterKey, id).Result()
+	if err == redis.Nil {
+		return Job{}, ErrJobNotFound
+	}
+	if err != nil {
+		return Job{}, err
+	}
+
+	var job Job
+	if err := json.Unmarshal([]byte(raw), &job); err != nil {
+		return Job{}, err
+	}
+
+	job.Status = models.JobStatusPending
+	job.RetryCount = 0
+	job.LastError = ""
+	job.FireAt = time.Now()
+
+	if err := q.Enqueue(ctx, job); err != nil {
+		return Job{}, err
+	}
+	q.client.HDel(ctx, deadLetterKey, id)
+	return job, nil
+}
@@ -0,0 +1,90 @@
+// Package scheduler persists deferred work as Jobs in a Queue, so reminder
+// delivery and recurrence materialization don't depend on a caller polling
+// GetUpcomingReminders/ProcessRecurringTodos at just the right moment. See
+// MemoryQueue for the single-process default and RedisQueue for a queue
+// shared across API instances.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"spicytodo-go-api/models"
+	"time"
+)
+
+// ErrJobNotFound is returned by Requeue when id doesn't name a
+// dead-lettered job.
+var ErrJobNotFound = errors.New("scheduler: job not found")
+
+// JobType identifies what a Job should do once it's due.
+type JobType string
+
+const (
+	// JobReminderFire delivers a todo's reminder notification.
+	JobReminderFire JobType = "reminder:fire"
+	// JobRecurrenceCreateNext materializes a recurring todo's next
+	// occurrence.
+	JobRecurrenceCreateNext JobType = "recurrence:create-next"
+	// JobCalDAVPush pushes a single todo to the configured CalDAV server.
+	JobCalDAVPush JobType = "caldav:push"
+)
+
+// DefaultMaxRetries is how many attempts a job gets (the first attempt plus
+// this many retries) before it's moved to the dead letter list, for jobs
+// that don't set their own MaxRetries.
+const DefaultMaxRetries = 3
+
+// Job is one unit of deferred work, due at FireAt. A job whose handler
+// fails is retried with Backoff up to MaxRetries times before it's moved to
+// the dead letter list (see Queue.MoveToDeadLetter).
+type Job struct {
+	ID         string           `json:"id"`
+	Type       JobType          `json:"type"`
+	TodoID     string           `json:"todoId"`
+	FireAt     time.Time        `json:"fireAt"`
+	Status     models.JobStatus `json:"status"`
+	RetryCount int              `json:"retryCount,omitempty"`
+	MaxRetries int              `json:"maxRetries,omitempty"`
+	LastError  string           `json:"lastError,omitempty"`
+}
+
+// Backoff returns how long to wait before retrying a job that has already
+// failed retryCount times: 1s, 2s, 4s, ... capped at 1 minute.
+func Backoff(retryCount int) time.Duration {
+	d := time.Second << retryCount
+	if d <= 0 || d > time.Minute {
+		return time.Minute
+	}
+	return d
+}
+
+// JobID derives a Job's ID from what it acts on, so re-enqueuing the same
+// todo/type pair (e.g. its reminder time changed) replaces the prior job
+// instead of leaving a stale duplicate behind.
+func JobID(todoID string, jobType JobType) string {
+	return todoID + "/" + string(jobType)
+}
+
+// Queue persists scheduled Jobs and hands back the ones that are due,
+// exactly once: two callers racing to claim the same due Job must not both
+// receive it. See MemoryQueue (tests, single-process default) and
+// RedisQueue (production, shared across API instances).
+type Queue interface {
+	// Enqueue schedules job, replacing any existing job with the same ID.
+	Enqueue(ctx context.Context, job Job) error
+	// Cancel removes a pending job, if one exists.
+	Cancel(ctx context.Context, id string) error
+	// Due atomically claims and removes up to limit jobs whose FireAt is
+	// at or before now, so no other caller can claim them too.
+	Due(ctx context.Context, now time.Time, limit int) ([]Job, error)
+	// List returns every pending job, soonest-first, for observability.
+	List(ctx context.Context) ([]Job, error)
+	// MoveToDeadLetter files job, which has exhausted its retries, for
+	// operator inspection via GET /api/worker/tasks/:id.
+	MoveToDeadLetter(ctx context.Context, job Job) error
+	// DeadLetter returns every dead-lettered job.
+	DeadLetter(ctx context.Context) ([]Job, error)
+	// Requeue moves a dead-lettered job back to pending, due immediately
+	// with its RetryCount reset, for POST /api/worker/tasks/:id/retry.
+	Requeue(ctx context.Context, id string) (Job, error)
+}
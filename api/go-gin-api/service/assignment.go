@@ -0,0 +1,153 @@
+package service
+
+import (
+	"spicytodo-go-api/events"
+	"spicytodo-go-api/models"
+	"sync"
+	"time"
+)
+
+// AgentService holds registered agents: operational config, not user data,
+// so like MaintenanceService it's a plain in-memory CRUD store with no
+// event log or search index.
+type AgentService struct {
+	mu     sync.RWMutex
+	agents map[string]*models.Agent
+}
+
+func NewAgentService() *AgentService {
+	return &AgentService{agents: make(map[string]*models.Agent)}
+}
+
+// List returns every registered agent, for `GET /api/agents`.
+func (a *AgentService) List() []*models.Agent {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	result := make([]*models.Agent, 0, len(a.agents))
+	for _, agent := range a.agents {
+		result = append(result, agent)
+	}
+	return result
+}
+
+// Register adds or replaces the agent identified by input.ID.
+func (a *AgentService) Register(input models.AgentCreate) *models.Agent {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	agent := &models.Agent{ID: input.ID, Labels: input.Labels}
+	a.agents[agent.ID] = agent
+	return agent
+}
+
+// best returns the highest-scoring registered agent for taskLabels, ties
+// broken by agent ID. ok is false if no registered agent matches.
+func (a *AgentService) best(taskLabels map[string]string) (*models.Agent, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var winner *models.Agent
+	bestScore := 0
+	for _, agent := range a.agents {
+		matched, score := matchScore(taskLabels, agent.Labels)
+		if !matched {
+			continue
+		}
+		if winner == nil || score > bestScore || (score == bestScore && agent.ID < winner.ID) {
+			winner, bestScore = agent, score
+		}
+	}
+	return winner, winner != nil
+}
+
+// matchScore scores how well agent's labels fit a task's labels: every
+// non-empty task label must exist on the agent, contributing +10 for an
+// exact value match or +1 for a wildcard ("*") value; any other mismatch
+// disqualifies the agent outright and short-circuits to (false, 0).
+func matchScore(taskLabels, agentLabels map[string]string) (bool, int) {
+	score := 0
+	for key, value := range taskLabels {
+		if value == "" {
+			continue
+		}
+		agentValue, ok := agentLabels[key]
+		if !ok {
+			return false, 0
+		}
+		switch agentValue {
+		case value:
+			score += 10
+		case "*":
+			score += 1
+		default:
+			return false, 0
+		}
+	}
+	return true, score
+}
+
+// ListAgents returns every registered agent, for `GET /api/agents`.
+func (s *TodoService) ListAgents() []*models.Agent {
+	return s.agents.List()
+}
+
+// RegisterAgent adds or replaces an agent that todos can be assigned to.
+func (s *TodoService) RegisterAgent(input models.AgentCreate) *models.Agent {
+	return s.agents.Register(input)
+}
+
+// AssignTodo assigns a single todo to its best-matching registered agent.
+// It returns (nil, false) if the todo doesn't exist, or (todo, false) if no
+// registered agent matches the todo's labels.
+func (s *TodoService) AssignTodo(id string) (*models.Todo, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	todo, exists := s.store.Get(id)
+	if !exists {
+		return nil, false
+	}
+
+	agent, ok := s.agents.best(todo.Labels)
+	if !ok {
+		return todo, false
+	}
+
+	s.assignTo(todo, agent)
+	return todo, true
+}
+
+// AssignPending assigns every unassigned todo to its best-matching
+// registered agent, returning how many todos were assigned.
+func (s *TodoService) AssignPending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var pending []*models.Todo
+	s.store.Range(func(todo *models.Todo) bool {
+		if todo.Assignee == nil {
+			pending = append(pending, todo)
+		}
+		return true
+	})
+
+	assigned := 0
+	for _, todo := range pending {
+		if agent, ok := s.agents.best(todo.Labels); ok {
+			s.assignTo(todo, agent)
+			assigned++
+		}
+	}
+	return assigned
+}
+
+// assignTo records agent as todo's assignee. Callers hold s.mu.
+func (s *TodoService) assignTo(todo *models.Todo, agent *models.Agent) {
+	assignee := agent.ID
+	todo.Assignee = &assignee
+	todo.UpdatedAt = time.Now()
+	s.store.Put(todo)
+	s.notify(events.TypeTodoUpdated, events.TodoUpdated{Todo: *todo}, todo.ID, todo)
+	s.pushCalDAV(todo)
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"spicytodo-go-api/models"
+	"testing"
+)
+
+func TestMatchScore(t *testing.T) {
+	tests := []struct {
+		name        string
+		taskLabels  map[string]string
+		agentLabels map[string]string
+		wantMatch   bool
+		wantScore   int
+	}{
+		{
+			name:        "No match",
+			taskLabels:  map[string]string{"skill": "backend"},
+			agentLabels: map[string]string{"skill": "frontend"},
+			wantMatch:   false,
+			wantScore:   0,
+		},
+		{
+			name:        "Missing key on agent",
+			taskLabels:  map[string]string{"skill": "backend"},
+			agentLabels: map[string]string{"region": "us"},
+			wantMatch:   false,
+			wantScore:   0,
+		},
+		{
+			name:        "Wildcard only",
+			taskLabels:  map[string]string{"skill": "backend"},
+			agentLabels: map[string]string{"skill": "*"},
+			wantMatch:   true,
+			wantScore:   1,
+		},
+		{
+			name:        "Exact match",
+			taskLabels:  map[string]string{"skill": "backend"},
+			agentLabels: map[string]string{"skill": "backend"},
+			wantMatch:   true,
+			wantScore:   10,
+		},
+		{
+			name:        "Mixed exact and wildcard",
+			taskLabels:  map[string]string{"skill": "backend", "region": "us"},
+			agentLabels: map[string]string{"skill": "backend", "region": "*"},
+			wantMatch:   true,
+			wantScore:   11,
+		},
+		{
+			name:        "Empty task label value is skipped",
+			taskLabels:  map[string]string{"skill": ""},
+			agentLabels: map[string]string{},
+			wantMatch:   true,
+			wantScore:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, score := matchScore(tt.taskLabels, tt.agentLabels)
+			if match != tt.wantMatch {
+				t.Errorf("matchScore() match = %v, want %v", match, tt.wantMatch)
+			}
+			if score != tt.wantScore {
+				t.Errorf("matchScore() score = %d, want %d", score, tt.wantScore)
+			}
+		})
+	}
+}
+
+func TestAssignTodoPicksHighestScoringAgent(t *testing.T) {
+	service := newTestServiceWithEvents(t)
+
+	service.RegisterAgent(models.AgentCreate{ID: "agent-wildcard", Labels: map[string]string{"skill": "*"}})
+	service.RegisterAgent(models.AgentCreate{ID: "agent-exact", Labels: map[string]string{"skill": "backend"}})
+
+	todo := service.Create(models.TodoCreate{Text: "Ship it", Labels: map[string]string{"skill": "backend"}})
+
+	assigned, ok := service.AssignTodo(todo.ID)
+	if !ok {
+		t.Fatalf("expected AssignTodo to find a matching agent")
+	}
+	if assigned.Assignee == nil || *assigned.Assignee != "agent-exact" {
+		t.Errorf("expected the exact-match agent to win over the wildcard agent, got assignee %v", assigned.Assignee)
+	}
+}
+
+func TestAssignTodoNoMatchingAgent(t *testing.T) {
+	service := newTestServiceWithEvents(t)
+
+	service.RegisterAgent(models.AgentCreate{ID: "agent-frontend", Labels: map[string]string{"skill": "frontend"}})
+
+	todo := service.Create(models.TodoCreate{Text: "Needs backend", Labels: map[string]string{"skill": "backend"}})
+
+	assigned, ok := service.AssignTodo(todo.ID)
+	if ok {
+		t.Error("expected no agent to match")
+	}
+	if assigned.Assignee != nil {
+		t.Error("expected todo to remain unassigned")
+	}
+}
+
+func TestAssignPendingSkipsAlreadyAssigned(t *testing.T) {
+	service := newTestServiceWithEvents(t)
+
+	service.RegisterAgent(models.AgentCreate{ID: "agent-1", Labels: map[string]string{"skill": "*"}})
+
+	first := service.Create(models.TodoCreate{Text: "First", Labels: map[string]string{"skill": "backend"}})
+	service.Create(models.TodoCreate{Text: "Second", Labels: map[string]string{"skill": "frontend"}})
+	service.Create(models.TodoCreate{Text: "Unlabeled"})
+
+	if _, ok := service.AssignTodo(first.ID); !ok {
+		t.Fatalf("expected first todo to be pre-assigned")
+	}
+
+	assigned := service.AssignPending()
+	if assigned != 2 {
+		t.Errorf("expected AssignPending to assign the 2 remaining unassigned todos, assigned %d", assigned)
+	}
+
+	todo, _ := service.GetByID(first.ID)
+	if todo.Assignee == nil || *todo.Assignee != "agent-1" {
+		t.Error("expected the already-assigned todo to keep its original assignee")
+	}
+}
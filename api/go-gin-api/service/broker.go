@@ -0,0 +1,169 @@
+package service
+
+import (
+	"spicytodo-go-api/models"
+	"sync"
+	"time"
+)
+
+// subscriberBuffer bounds how many undelivered events a slow subscriber can
+// accumulate before new events are dropped for it.
+const subscriberBuffer = 32
+
+// ChangeEvent is published to SSE subscribers whenever a mutating method
+// changes todo state. Todo is nil for deletes, where only the ID survives.
+type ChangeEvent struct {
+	Seq  uint64       `json:"seq"`
+	Type string       `json:"type"`
+	ID   string       `json:"id"`
+	Todo *models.Todo `json:"todo,omitempty"`
+	At   time.Time    `json:"at"`
+}
+
+// StreamFilter narrows a subscription to events matching a tag, category,
+// priority, completion status, event type, and/or todo ID. Empty fields
+// match everything.
+type StreamFilter struct {
+	Tag      string
+	Category string
+	Priority string
+	ID       string
+	// Status narrows by completion state: "active" or "completed". Empty
+	// matches both.
+	Status string
+	// EventType narrows to a single event Type (e.g. "ReminderDue"), for
+	// GET /api/reminders/stream. Empty matches every type.
+	EventType string
+	// EventTypes narrows to any of several event Types, for
+	// GET /api/todos/reminders/stream. Empty/nil matches every type.
+	EventTypes []string
+}
+
+func (f StreamFilter) matches(event ChangeEvent) bool {
+	if f.ID != "" && event.ID != f.ID {
+		return false
+	}
+	if f.EventType != "" && event.Type != f.EventType {
+		return false
+	}
+	if len(f.EventTypes) > 0 && !containsStr(f.EventTypes, event.Type) {
+		return false
+	}
+	if f.Tag == "" && f.Category == "" && f.Priority == "" && f.Status == "" {
+		return true
+	}
+	// Deletes carry no Todo payload, so filtered subscribers can't match
+	// on tag/category/priority/status; let them through rather than
+	// silently hiding deletions from a filtered view.
+	if event.Todo == nil {
+		return true
+	}
+
+	if f.Status == "active" && event.Todo.Completed {
+		return false
+	}
+	if f.Status == "completed" && !event.Todo.Completed {
+		return false
+	}
+
+	if f.Priority != "" && string(event.Todo.Priority) != f.Priority {
+		return false
+	}
+	if f.Category != "" && (event.Todo.Category == nil || *event.Todo.Category != f.Category) {
+		return false
+	}
+	if f.Tag != "" {
+		found := false
+		for _, tag := range event.Todo.Tags {
+			if tag == f.Tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+type subscriber struct {
+	ch     chan ChangeEvent
+	filter StreamFilter
+}
+
+// Hub is an in-process pub/sub broker for todo change events, backing the
+// SSE stream at GET /api/todos/stream.
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[int]*subscriber
+	nextID      int
+}
+
+// NewHub builds an empty Hub.
+func NewHub() *Hub {
+	return &Hub{subscribers: make(map[int]*subscriber)}
+}
+
+// Subscribe registers a new subscriber matching filter and returns its ID
+// (for Unsubscribe) and a receive-only channel of matching events.
+func (h *Hub) Subscribe(filter StreamFilter) (int, <-chan ChangeEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.nextID++
+	id := h.nextID
+	sub := &subscriber{ch: make(chan ChangeEvent, subscriberBuffer), filter: filter}
+	h.subscribers[id] = sub
+	return id, sub.ch
+}
+
+// Unsubscribe removes and closes a subscriber's channel.
+func (h *Hub) Unsubscribe(id int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if sub, ok := h.subscribers[id]; ok {
+		delete(h.subscribers, id)
+		close(sub.ch)
+	}
+}
+
+// Publish fans event out to every subscriber whose filter matches. Sends
+// are non-blocking: a subscriber that isn't keeping up has its oldest
+// queued event evicted to make room, and gets a "Dropped" event in its
+// place so it knows to resync via GET /api/todos instead of trusting its
+// stream to be gap-free.
+func (h *Hub) Publish(event ChangeEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, sub := range h.subscribers {
+		if !sub.filter.matches(event) {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+			select {
+			case <-sub.ch:
+			default:
+			}
+			select {
+			case sub.ch <- ChangeEvent{Type: "Dropped", At: time.Now()}:
+			default:
+			}
+		}
+	}
+}
+
+// containsStr reports whether s is present in list.
+func containsStr(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"spicytodo-go-api/models"
+	"testing"
+)
+
+func TestStreamFilterMatchesByStatus(t *testing.T) {
+	active := ChangeEvent{Todo: &models.Todo{Completed: false}}
+	completed := ChangeEvent{Todo: &models.Todo{Completed: true}}
+
+	activeFilter := StreamFilter{Status: "active"}
+	if !activeFilter.matches(active) {
+		t.Error("expected filter=active to match an uncompleted todo")
+	}
+	if activeFilter.matches(completed) {
+		t.Error("expected filter=active to reject a completed todo")
+	}
+
+	completedFilter := StreamFilter{Status: "completed"}
+	if completedFilter.matches(active) {
+		t.Error("expected filter=completed to reject an uncompleted todo")
+	}
+	if !completedFilter.matches(completed) {
+		t.Error("expected filter=completed to match a completed todo")
+	}
+}
+
+func TestStreamFilterMatchesByEventType(t *testing.T) {
+	filter := StreamFilter{EventType: "ReminderDue"}
+
+	if !filter.matches(ChangeEvent{Type: "ReminderDue"}) {
+		t.Error("expected the filter to match its own event type")
+	}
+	if filter.matches(ChangeEvent{Type: "TodoCreated"}) {
+		t.Error("expected the filter to reject other event types")
+	}
+}
+
+func TestStreamFilterStatusLetsDeletesThrough(t *testing.T) {
+	filter := StreamFilter{Status: "completed"}
+
+	if !filter.matches(ChangeEvent{Type: "TodoDeleted", Todo: nil}) {
+		t.Error("expected a delete event (no Todo payload) to pass a status filter")
+	}
+}
+
+func TestStreamFilterMatchesByEventTypes(t *testing.T) {
+	filter := StreamFilter{EventTypes: []string{"ReminderDue", "TodoSnoozed"}}
+
+	if !filter.matches(ChangeEvent{Type: "TodoSnoozed"}) {
+		t.Error("expected the filter to match one of its listed event types")
+	}
+	if filter.matches(ChangeEvent{Type: "TodoCreated"}) {
+		t.Error("expected the filter to reject a type not in its list")
+	}
+}
+
+func TestHubPublishEvictsOldestAndMarksDropped(t *testing.T) {
+	hub := NewHub()
+	_, events := hub.Subscribe(StreamFilter{})
+
+	for i := 0; i < subscriberBuffer+1; i++ {
+		hub.Publish(ChangeEvent{Type: "TodoCreated", ID: string(rune('a' + i))})
+	}
+
+	var last ChangeEvent
+	for i := 0; i < subscriberBuffer; i++ {
+		last = <-events
+	}
+
+	if last.Type != "Dropped" {
+		t.Errorf("expected the last queued event to be a Dropped marker, got %+v", last)
+	}
+}
@@ -0,0 +1,497 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"spicytodo-go-api/events"
+	"spicytodo-go-api/models"
+	"spicytodo-go-api/scheduler"
+	"strings"
+	"time"
+)
+
+// ErrCalDAVNotConfigured is returned by PullCalDAV/PushAllCalDAV when no
+// CalDAV client has been wired in (see SetCalDAVClient).
+var ErrCalDAVNotConfigured = errors.New("caldav sync is not configured")
+
+// CalDAVConfig addresses a CalDAV server's collection of VTODO calendar
+// objects: BaseURL+CalendarPath is the collection itself, and
+// BaseURL+CalendarPath+<uid>+".ics" is one todo's object.
+type CalDAVConfig struct {
+	BaseURL      string
+	Username     string
+	Password     string
+	CalendarPath string
+}
+
+// defaultCalDAVCalendarPath is used when CALDAV_CALENDAR_PATH is not set.
+const defaultCalDAVCalendarPath = "/calendars/spicytodo/"
+
+// caldavConfigFromEnv reads CALDAV_URL/CALDAV_USERNAME/CALDAV_PASSWORD/
+// CALDAV_CALENDAR_PATH the same way newTodoStoreFromEnv reads STORE: CalDAV
+// sync is opt-in, so an unset CALDAV_URL reports ok=false rather than an
+// error.
+func caldavConfigFromEnv() (cfg CalDAVConfig, ok bool) {
+	baseURL := os.Getenv("CALDAV_URL")
+	if baseURL == "" {
+		return CalDAVConfig{}, false
+	}
+
+	path := os.Getenv("CALDAV_CALENDAR_PATH")
+	if path == "" {
+		path = defaultCalDAVCalendarPath
+	}
+
+	return CalDAVConfig{
+		BaseURL:      strings.TrimRight(baseURL, "/"),
+		Username:     os.Getenv("CALDAV_USERNAME"),
+		Password:     os.Getenv("CALDAV_PASSWORD"),
+		CalendarPath: path,
+	}, true
+}
+
+// CalDAVClient speaks just enough of WebDAV/CalDAV to list, fetch, create,
+// and delete VTODO calendar objects with basic auth: a PROPFIND for
+// listing, and plain GET/PUT/DELETE for the rest. It reuses EncodeICSTo's
+// and DecodeICS's VTODO property mapping rather than pulling in a
+// third-party CalDAV library.
+type CalDAVClient struct {
+	cfg        CalDAVConfig
+	httpClient *http.Client
+}
+
+// NewCalDAVClient builds a client for cfg's server.
+func NewCalDAVClient(cfg CalDAVConfig) *CalDAVClient {
+	return &CalDAVClient{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (c *CalDAVClient) objectURL(uid string) string {
+	return c.cfg.BaseURL + c.cfg.CalendarPath + uid + ".ics"
+}
+
+func (c *CalDAVClient) request(ctx context.Context, method, url string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if c.cfg.Username != "" || c.cfg.Password != "" {
+		req.SetBasicAuth(c.cfg.Username, c.cfg.Password)
+	}
+	return c.httpClient.Do(req)
+}
+
+// Push writes todo to the server as a single-VTODO VCALENDAR document,
+// creating or overwriting its calendar object at CalendarPath+todo.ID+
+// ".ics".
+func (c *CalDAVClient) Push(ctx context.Context, todo models.Todo) error {
+	var buf bytes.Buffer
+	if err := EncodeICSTo(&buf, []models.Todo{todo}); err != nil {
+		return fmt.Errorf("caldav: encode %s: %w", todo.ID, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.objectURL(todo.ID), &buf)
+	if err != nil {
+		return err
+	}
+	if c.cfg.Username != "" || c.cfg.Password != "" {
+		req.SetBasicAuth(c.cfg.Username, c.cfg.Password)
+	}
+	req.Header.Set("Content-Type", "text/calendar; charset=utf-8")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("caldav: PUT %s: %w", todo.ID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("caldav: PUT %s: unexpected status %s", todo.ID, resp.Status)
+	}
+	return nil
+}
+
+// Delete removes id's calendar object. A 404 is treated as success, since
+// the end state a caller wants (no remote object for id) already holds.
+func (c *CalDAVClient) Delete(ctx context.Context, id string) error {
+	resp, err := c.request(ctx, http.MethodDelete, c.objectURL(id), nil)
+	if err != nil {
+		return fmt.Errorf("caldav: DELETE %s: %w", id, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("caldav: DELETE %s: unexpected status %s", id, resp.Status)
+	}
+	return nil
+}
+
+// remoteTodo is one VTODO pulled from the server: its UID (which Push sets
+// to the local todo's ID, so a round trip lands back on the same todo), its
+// LAST-MODIFIED (used by applyRemoteCalDAV to resolve conflicts against the
+// local copy's UpdatedAt), alongside the fields DecodeICS already knows how
+// to extract.
+type remoteTodo struct {
+	UID          string
+	LastModified time.Time
+	Todo         models.TodoCreate
+}
+
+// propfindListBody requests just enough (getetag, to force a property
+// response per member) to enumerate the collection's hrefs.
+const propfindListBody = `<?xml version="1.0" encoding="utf-8" ?>` +
+	`<D:propfind xmlns:D="DAV:"><D:prop><D:getetag/></D:prop></D:propfind>`
+
+type davMultistatus struct {
+	Responses []davResponse `xml:"response"`
+}
+
+type davResponse struct {
+	Href string `xml:"href"`
+}
+
+// listObjectHrefs PROPFINDs the calendar collection (Depth: 1) and returns
+// the hrefs of its .ics members.
+func (c *CalDAVClient) listObjectHrefs(ctx context.Context) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, "PROPFIND", c.cfg.BaseURL+c.cfg.CalendarPath, strings.NewReader(propfindListBody))
+	if err != nil {
+		return nil, err
+	}
+	if c.cfg.Username != "" || c.cfg.Password != "" {
+		req.SetBasicAuth(c.cfg.Username, c.cfg.Password)
+	}
+	req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+	req.Header.Set("Depth", "1")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("caldav: PROPFIND: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("caldav: PROPFIND: unexpected status %s", resp.Status)
+	}
+
+	var ms davMultistatus
+	if err := xml.NewDecoder(resp.Body).Decode(&ms); err != nil {
+		return nil, fmt.Errorf("caldav: PROPFIND: decoding response: %w", err)
+	}
+
+	hrefs := make([]string, 0, len(ms.Responses))
+	for _, r := range ms.Responses {
+		if strings.HasSuffix(r.Href, ".ics") {
+			hrefs = append(hrefs, r.Href)
+		}
+	}
+	return hrefs, nil
+}
+
+func (c *CalDAVClient) resolveHref(href string) string {
+	if strings.HasPrefix(href, "http://") || strings.HasPrefix(href, "https://") {
+		return href
+	}
+	return c.cfg.BaseURL + href
+}
+
+// fetch GETs one calendar object and parses its (single) VTODO.
+func (c *CalDAVClient) fetch(ctx context.Context, href string) (remoteTodo, error) {
+	resp, err := c.request(ctx, http.MethodGet, c.resolveHref(href), nil)
+	if err != nil {
+		return remoteTodo{}, fmt.Errorf("caldav: GET %s: %w", href, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return remoteTodo{}, fmt.Errorf("caldav: GET %s: unexpected status %s", href, resp.Status)
+	}
+
+	return decodeSingleVTodo(resp.Body)
+}
+
+// decodeSingleVTodo parses a VCALENDAR document's first VTODO, the same
+// way DecodeICS's loop does, but also keeping the UID property DecodeICS
+// itself discards (TodoCreate has no ID field to put it in).
+func decodeSingleVTodo(r io.Reader) (remoteTodo, error) {
+	var current map[string]icsProperty
+	inAlarm := false
+
+	for _, line := range unfoldICSLines(r) {
+		switch {
+		case line == "BEGIN:VTODO":
+			current = make(map[string]icsProperty)
+
+		case line == "END:VTODO":
+			if current == nil {
+				continue
+			}
+			uid := current["UID"].value
+			if uid == "" {
+				return remoteTodo{}, fmt.Errorf("caldav: VTODO missing UID")
+			}
+			todo, err := todoFromICSProperties(current)
+			if err != nil {
+				return remoteTodo{}, fmt.Errorf("caldav: VTODO %s: %w", uid, err)
+			}
+			return remoteTodo{UID: uid, LastModified: lastModifiedFromICS(current), Todo: todo}, nil
+
+		case line == "BEGIN:VALARM":
+			inAlarm = true
+
+		case line == "END:VALARM":
+			inAlarm = false
+
+		case current != nil && !inAlarm && line != "":
+			name, params, value := parseICSLine(line)
+			current[name] = icsProperty{params: params, value: value}
+		}
+	}
+
+	return remoteTodo{}, fmt.Errorf("caldav: no VTODO found")
+}
+
+// lastModifiedFromICS parses a VTODO's LAST-MODIFIED property, returning the
+// zero Time if it's absent or malformed so a caller can treat that the same
+// as "always apply the remote copy" (there's nothing to compare against).
+func lastModifiedFromICS(props map[string]icsProperty) time.Time {
+	p, ok := props["LAST-MODIFIED"]
+	if !ok {
+		return time.Time{}
+	}
+	t, err := time.Parse(icsDateTimeFormat, p.value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// Pull lists and fetches every calendar object in the collection. A single
+// object that fails to fetch or parse is logged and skipped rather than
+// failing the whole pull, the same "best effort over all-or-nothing"
+// tradeoff ImportTodos makes for individual bad rows.
+func (c *CalDAVClient) Pull(ctx context.Context) ([]remoteTodo, error) {
+	hrefs, err := c.listObjectHrefs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	remotes := make([]remoteTodo, 0, len(hrefs))
+	for _, href := range hrefs {
+		remote, err := c.fetch(ctx, href)
+		if err != nil {
+			log.Printf("caldav: skipping %s: %v", href, err)
+			continue
+		}
+		remotes = append(remotes, remote)
+	}
+	return remotes, nil
+}
+
+// SetCalDAVClient wires the CalDAV client Create/Update/Toggle/Delete push
+// changes through in the background, and PullCalDAV/PushAllCalDAV sync
+// against. Optional: until it's called (including in every test that
+// constructs a TodoService directly), sync is simply disabled.
+func (s *TodoService) SetCalDAVClient(client *CalDAVClient) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.caldav = client
+}
+
+// CalDAVConfigured reports whether a client was wired in, so handlers can
+// reject a sync request up front instead of surfacing a confusing
+// lower-level failure.
+func (s *TodoService) CalDAVConfigured() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.caldav != nil
+}
+
+// pushCalDAV schedules a caldav:push job for todo: a todo mutation
+// shouldn't have to wait on (or fail because of) an unreachable calendar
+// server, so the push happens on ProcessJobQueue's next pass instead, with
+// retries and backoff (see dispatchCalDAVPush) rather than a single
+// best-effort attempt.
+func (s *TodoService) pushCalDAV(todo *models.Todo) {
+	if s.caldav == nil || todo == nil {
+		return
+	}
+	s.jobQueue.Enqueue(context.Background(), scheduler.Job{
+		ID:     scheduler.JobID(todo.ID, scheduler.JobCalDAVPush),
+		Type:   scheduler.JobCalDAVPush,
+		TodoID: todo.ID,
+		FireAt: time.Now(),
+		Status: models.JobStatusPending,
+	})
+}
+
+// dispatchCalDAVPush pushes id's current state to the CalDAV server,
+// returning the client's error so ProcessJobQueue can retry it.
+func (s *TodoService) dispatchCalDAVPush(id string) error {
+	s.mu.RLock()
+	todo, exists := s.store.Get(id)
+	client := s.caldav
+	s.mu.RUnlock()
+
+	if !exists || client == nil {
+		return nil
+	}
+	return client.Push(context.Background(), *todo)
+}
+
+// deleteCalDAV removes id's calendar object in the background; see
+// pushCalDAV for why failures are only logged.
+func (s *TodoService) deleteCalDAV(id string) {
+	if s.caldav == nil {
+		return
+	}
+	go func(client *CalDAVClient, id string) {
+		if err := client.Delete(context.Background(), id); err != nil {
+			log.Printf("caldav: %v", err)
+		}
+	}(s.caldav, id)
+}
+
+// PushAllCalDAV synchronously pushes every local todo's current state to
+// the CalDAV server, for `POST /api/sync/caldav/push`. Unlike the
+// push-on-mutation calls Create/Update/Toggle make, this blocks until
+// done and reports how many succeeded; a single todo's push failure is
+// logged and doesn't stop the rest.
+func (s *TodoService) PushAllCalDAV() (int, error) {
+	s.mu.RLock()
+	client := s.caldav
+	s.mu.RUnlock()
+	if client == nil {
+		return 0, ErrCalDAVNotConfigured
+	}
+
+	pushed := 0
+	for _, todo := range s.snapshotTodos() {
+		if err := client.Push(context.Background(), *todo); err != nil {
+			log.Printf("caldav: %v", err)
+			continue
+		}
+		pushed++
+	}
+	return pushed, nil
+}
+
+// PullCalDAV fetches every VTODO from the CalDAV server and reconciles the
+// local store by UID, for `POST /api/sync/caldav/pull`: a UID matching an
+// existing todo's ID overwrites that todo's fields from the remote copy
+// (a manual pull treats the server as authoritative, the same way
+// /api/admin/replay treats the event log as authoritative for a rebuild);
+// an unmatched UID is created as a new todo, keeping the remote UID as its
+// ID so future pushes land back on the same calendar object. Returns how
+// many remote objects were reconciled.
+func (s *TodoService) PullCalDAV() (int, error) {
+	s.mu.RLock()
+	client := s.caldav
+	s.mu.RUnlock()
+	if client == nil {
+		return 0, ErrCalDAVNotConfigured
+	}
+
+	remotes, err := client.Pull(context.Background())
+	if err != nil {
+		return 0, err
+	}
+
+	for _, remote := range remotes {
+		s.applyRemoteCalDAV(remote.UID, remote.Todo, remote.LastModified)
+	}
+	return len(remotes), nil
+}
+
+// SyncCalDAV runs a full two-way sync for `POST /api/sync/caldav/sync`:
+// pull reconciles the local store from the server first, then push
+// backfills the server with the (now pull-reconciled) local state, so a
+// todo changed on either side since the last sync ends up consistent on
+// both.
+func (s *TodoService) SyncCalDAV() (pulled, pushed int, err error) {
+	pulled, err = s.PullCalDAV()
+	if err != nil {
+		return 0, 0, err
+	}
+	pushed, err = s.PushAllCalDAV()
+	if err != nil {
+		return pulled, 0, err
+	}
+	return pulled, pushed, nil
+}
+
+// applyRemoteCalDAV reconciles one pulled VTODO against the local store;
+// see PullCalDAV for the UID-matching semantics. lastModified is the
+// remote VTODO's LAST-MODIFIED: when it's older than or equal to an
+// existing local todo's UpdatedAt, the local copy already reflects (or
+// postdates) whatever produced the remote object, so it's left alone
+// rather than being clobbered by a stale pull.
+func (s *TodoService) applyRemoteCalDAV(uid string, input models.TodoCreate, lastModified time.Time) *models.Todo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if input.Priority == "" {
+		input.Priority = models.PriorityMedium
+	}
+	now := time.Now()
+
+	if todo, exists := s.store.Get(uid); exists {
+		if !lastModified.IsZero() && !lastModified.After(todo.UpdatedAt) {
+			return todo
+		}
+		before := *todo
+		todo.Text = input.Text
+		todo.Priority = input.Priority
+		todo.Completed = input.Completed
+		todo.DueDate = input.DueDate
+		todo.StartDate = input.StartDate
+		todo.Reminders = input.Reminders
+		todo.RecurrenceRule = input.RecurrenceRule
+		todo.Recurrence = input.Recurrence
+		todo.Tags = input.Tags
+		todo.Category = input.Category
+		todo.UpdatedAt = now
+		s.store.Put(todo)
+		s.notify(events.TypeTodoUpdated, events.TodoUpdated{Todo: *todo}, todo.ID, todo)
+		if s.searchIndex != nil {
+			s.searchIndex.updateTodo(&before, todo)
+		}
+		if s.scheduler != nil {
+			s.scheduler.syncTodo(todo)
+		}
+		return todo
+	}
+
+	todo := &models.Todo{
+		ID:             uid,
+		Text:           input.Text,
+		Priority:       input.Priority,
+		Completed:      input.Completed,
+		DueDate:        input.DueDate,
+		StartDate:      input.StartDate,
+		Reminders:      input.Reminders,
+		RecurrenceRule: input.RecurrenceRule,
+		Recurrence:     input.Recurrence,
+		Tags:           input.Tags,
+		Category:       input.Category,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	s.notify(events.TypeTodoCreated, events.TodoCreated{Todo: *todo}, todo.ID, todo)
+	s.store.Put(todo)
+	if s.searchIndex != nil {
+		s.searchIndex.indexTodo(todo)
+	}
+	if s.scheduler != nil {
+		s.scheduler.syncTodo(todo)
+	}
+	return todo
+}
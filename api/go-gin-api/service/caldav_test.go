@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"spicytodo-go-api/models"
+)
+
+func TestCalDAVConfigFromEnvDisabledByDefault(t *testing.T) {
+	if _, ok := caldavConfigFromEnv(); ok {
+		t.Error("expected caldavConfigFromEnv to report disabled when CALDAV_URL is unset")
+	}
+}
+
+func TestCalDAVConfigFromEnvDefaultsCalendarPath(t *testing.T) {
+	t.Setenv("CALDAV_URL", "https://caldav.example.com")
+	t.Setenv("CALDAV_CALENDAR_PATH", "")
+
+	cfg, ok := caldavConfigFromEnv()
+	if !ok {
+		t.Fatal("expected caldavConfigFromEnv to report enabled when CALDAV_URL is set")
+	}
+	if cfg.CalendarPath != defaultCalDAVCalendarPath {
+		t.Errorf("CalendarPath = %q, want %q", cfg.CalendarPath, defaultCalDAVCalendarPath)
+	}
+}
+
+// caldavTestServer fakes just enough of a CalDAV server to exercise
+// CalDAVClient: PUT/GET/DELETE on individual .ics objects, and a PROPFIND
+// multistatus listing them, all behind an in-memory map keyed by UID.
+func caldavTestServer(t *testing.T) (*httptest.Server, map[string]string) {
+	t.Helper()
+	objects := map[string]string{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/calendars/spicytodo/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PROPFIND" {
+			var sb strings.Builder
+			sb.WriteString(`<?xml version="1.0"?><D:multistatus xmlns:D="DAV:">`)
+			for uid := range objects {
+				fmt.Fprintf(&sb, `<D:response><D:href>/calendars/spicytodo/%s.ics</D:href></D:response>`, uid)
+			}
+			sb.WriteString(`</D:multistatus>`)
+			w.Header().Set("Content-Type", "application/xml")
+			w.Write([]byte(sb.String()))
+			return
+		}
+
+		uid := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/calendars/spicytodo/"), ".ics")
+		switch r.Method {
+		case http.MethodPut:
+			body := make([]byte, r.ContentLength)
+			r.Body.Read(body)
+			objects[uid] = string(body)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodGet:
+			body, ok := objects[uid]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(body))
+		case http.MethodDelete:
+			delete(objects, uid)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, objects
+}
+
+func TestCalDAVClientPushGetDeleteRoundTrip(t *testing.T) {
+	server, objects := caldavTestServer(t)
+	client := NewCalDAVClient(CalDAVConfig{BaseURL: server.URL, CalendarPath: "/calendars/spicytodo/"})
+
+	dueDate := "2026-08-01"
+	todo := models.Todo{ID: "abc-123", Text: "Ship it", Priority: models.PriorityHigh, DueDate: &dueDate}
+
+	if err := client.Push(context.Background(), todo); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+	if _, ok := objects["abc-123"]; !ok {
+		t.Fatal("expected the server to have stored the pushed object")
+	}
+
+	remotes, err := client.Pull(context.Background())
+	if err != nil {
+		t.Fatalf("Pull: %v", err)
+	}
+	if len(remotes) != 1 || remotes[0].UID != "abc-123" || remotes[0].Todo.Text != "Ship it" {
+		t.Fatalf("Pull() = %+v, want one remote todo abc-123/\"Ship it\"", remotes)
+	}
+
+	if err := client.Delete(context.Background(), "abc-123"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, ok := objects["abc-123"]; ok {
+		t.Error("expected the server to no longer have the deleted object")
+	}
+
+	// Deleting an already-absent object is treated as success.
+	if err := client.Delete(context.Background(), "abc-123"); err != nil {
+		t.Errorf("Delete of already-absent object: %v", err)
+	}
+}
+
+func TestPullCalDAVCreatesAndUpdatesByUID(t *testing.T) {
+	server, _ := caldavTestServer(t)
+	client := NewCalDAVClient(CalDAVConfig{BaseURL: server.URL, CalendarPath: "/calendars/spicytodo/"})
+
+	service := newIsolatedTodoService(t)
+	existing := service.Create(models.TodoCreate{Text: "Old text"})
+
+	// Seed the fake server directly (bypassing Push) with one VTODO that
+	// reuses existing's ID, so PullCalDAV should update it in place, and one
+	// with a brand new UID, which it should create.
+	dueDate := "2026-09-01"
+	if err := client.Push(context.Background(), models.Todo{ID: existing.ID, Text: "Updated from server", Priority: models.PriorityHigh, DueDate: &dueDate}); err != nil {
+		t.Fatalf("seeding existing object: %v", err)
+	}
+	if err := client.Push(context.Background(), models.Todo{ID: "brand-new-uid", Text: "New from server"}); err != nil {
+		t.Fatalf("seeding new object: %v", err)
+	}
+
+	service.SetCalDAVClient(client)
+
+	pulled, err := service.PullCalDAV()
+	if err != nil {
+		t.Fatalf("PullCalDAV: %v", err)
+	}
+	if pulled != 2 {
+		t.Errorf("pulled = %d, want 2", pulled)
+	}
+
+	updated, ok := service.GetByID(existing.ID)
+	if !ok || updated.Text != "Updated from server" {
+		t.Errorf("expected %s to be updated from the remote copy, got %+v", existing.ID, updated)
+	}
+
+	created, ok := service.GetByID("brand-new-uid")
+	if !ok || created.Text != "New from server" {
+		t.Error("expected a new todo to be created with the remote UID as its ID")
+	}
+}
+
+func TestPullCalDAVKeepsLocalWhenNewerThanRemote(t *testing.T) {
+	server, _ := caldavTestServer(t)
+	client := NewCalDAVClient(CalDAVConfig{BaseURL: server.URL, CalendarPath: "/calendars/spicytodo/"})
+
+	// Seed the server with a stale LAST-MODIFIED in the past.
+	if err := client.Push(context.Background(), models.Todo{
+		ID:        "stale-remote",
+		Text:      "From server",
+		UpdatedAt: time.Now().Add(-time.Hour),
+	}); err != nil {
+		t.Fatalf("seeding object: %v", err)
+	}
+
+	service := newIsolatedTodoService(t)
+	existing := service.Create(models.TodoCreate{Text: "Edited locally after the remote object was written"})
+	service.SetCalDAVClient(client)
+
+	if _, err := service.PullCalDAV(); err != nil {
+		t.Fatalf("PullCalDAV: %v", err)
+	}
+
+	// existing's ID doesn't match the seeded "stale-remote" UID, so pulling
+	// creates a separate todo for it and leaves existing untouched; what
+	// this test actually exercises is that a remote LAST-MODIFIED in the
+	// past never wins against a local UpdatedAt that postdates it.
+	local, ok := service.GetByID(existing.ID)
+	if !ok || local.Text != "Edited locally after the remote object was written" {
+		t.Fatalf("expected the local todo to survive the pull untouched, got %+v", local)
+	}
+
+	remote, ok := service.GetByID("stale-remote")
+	if !ok {
+		t.Fatal("expected the stale remote object to still be created as a new local todo")
+	}
+
+	// Re-push the same stale object directly over remote's now-current
+	// local UpdatedAt and pull again: the stale LAST-MODIFIED must not
+	// clobber the local copy this time.
+	if err := client.Push(context.Background(), models.Todo{
+		ID:        "stale-remote",
+		Text:      "Stale text from server",
+		UpdatedAt: remote.UpdatedAt.Add(-time.Hour),
+	}); err != nil {
+		t.Fatalf("re-seeding object: %v", err)
+	}
+	if _, err := service.PullCalDAV(); err != nil {
+		t.Fatalf("PullCalDAV: %v", err)
+	}
+
+	after, _ := service.GetByID("stale-remote")
+	if after.Text != remote.Text {
+		t.Errorf("expected a stale remote LAST-MODIFIED to leave the local copy alone, got text %q", after.Text)
+	}
+}
+
+func TestSyncCalDAVPullsThenPushes(t *testing.T) {
+	server, objects := caldavTestServer(t)
+	client := NewCalDAVClient(CalDAVConfig{BaseURL: server.URL, CalendarPath: "/calendars/spicytodo/"})
+
+	if err := client.Push(context.Background(), models.Todo{ID: "remote-only", Text: "From server"}); err != nil {
+		t.Fatalf("seeding object: %v", err)
+	}
+
+	service := newTestServiceWithEvents(t)
+	service.Create(models.TodoCreate{Text: "Local only"})
+	service.SetCalDAVClient(client)
+
+	pulled, pushed, err := service.SyncCalDAV()
+	if err != nil {
+		t.Fatalf("SyncCalDAV: %v", err)
+	}
+	if pulled != 1 {
+		t.Errorf("pulled = %d, want 1", pulled)
+	}
+	// Both the pre-existing local todo and the just-pulled remote one get
+	// pushed back, so the server ends up with both.
+	if pushed != 2 {
+		t.Errorf("pushed = %d, want 2", pushed)
+	}
+	if len(objects) != 2 {
+		t.Errorf("expected the server to end up with 2 objects, got %d", len(objects))
+	}
+}
+
+func TestPullCalDAVNotConfigured(t *testing.T) {
+	service := newIsolatedTodoService(t)
+
+	if service.CalDAVConfigured() {
+		t.Fatal("expected a freshly constructed service to have CalDAV sync disabled")
+	}
+	if _, err := service.PullCalDAV(); err != ErrCalDAVNotConfigured {
+		t.Errorf("PullCalDAV() err = %v, want ErrCalDAVNotConfigured", err)
+	}
+	if _, err := service.PushAllCalDAV(); err != ErrCalDAVNotConfigured {
+		t.Errorf("PushAllCalDAV() err = %v, want ErrCalDAVNotConfigured", err)
+	}
+}
+
+func TestPushAllCalDAVOfflineServerDoesNotFailOtherTodos(t *testing.T) {
+	service := newIsolatedTodoService(t)
+	service.Create(models.TodoCreate{Text: "First"})
+	service.Create(models.TodoCreate{Text: "Second"})
+
+	// An unreachable server (nothing listening on this port): every push
+	// fails, but PushAllCalDAV should still finish and report 0 pushed
+	// rather than aborting partway through.
+	service.SetCalDAVClient(NewCalDAVClient(CalDAVConfig{BaseURL: "http://127.0.0.1:1", CalendarPath: "/calendars/spicytodo/"}))
+
+	pushed, err := service.PushAllCalDAV()
+	if err != nil {
+		t.Fatalf("PushAllCalDAV: %v", err)
+	}
+	if pushed != 0 {
+		t.Errorf("pushed = %d, want 0 against an unreachable server", pushed)
+	}
+}
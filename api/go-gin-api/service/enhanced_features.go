@@ -1,7 +1,12 @@
 package service
 
 import (
+	"context"
+	"fmt"
+	"log"
+	"spicytodo-go-api/events"
 	"spicytodo-go-api/models"
+	"spicytodo-go-api/recurrence"
 	"strconv"
 	"strings"
 	"time"
@@ -12,13 +17,18 @@ func (s *TodoService) Snooze(id string, until time.Time) (*models.Todo, bool) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	todo, exists := s.todos[id]
+	todo, exists := s.store.Get(id)
 	if !exists {
 		return nil, false
 	}
 
 	todo.SnoozedUntil = &until
 	todo.UpdatedAt = time.Now()
+	s.store.Put(todo)
+	s.notify(events.TypeTodoSnoozed, events.TodoSnoozed{ID: todo.ID, Until: &until}, todo.ID, todo)
+	if s.scheduler != nil {
+		s.scheduler.syncTodo(todo)
+	}
 	return todo, true
 }
 
@@ -27,59 +37,278 @@ func (s *TodoService) Unsnooze(id string) (*models.Todo, bool) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	todo, exists := s.todos[id]
+	todo, exists := s.store.Get(id)
 	if !exists {
 		return nil, false
 	}
 
 	todo.SnoozedUntil = nil
 	todo.UpdatedAt = time.Now()
+	s.store.Put(todo)
+	s.notify(events.TypeTodoSnoozed, events.TodoSnoozed{ID: todo.ID, Until: nil}, todo.ID, todo)
+	if s.scheduler != nil {
+		s.scheduler.syncTodo(todo)
+	}
+	return todo, true
+}
+
+// SetRecurrence sets a todo's RecurrenceRule/Recurrence RRULE so it spawns a
+// new occurrence when completed or overdue (see ProcessRecurringTodos).
+func (s *TodoService) SetRecurrence(id string, rule models.RecurrenceRule, rrule string) (*models.Todo, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	todo, exists := s.store.Get(id)
+	if !exists {
+		return nil, false
+	}
+
+	todo.RecurrenceRule = rule
+	todo.Recurrence = rrule
+	todo.UpdatedAt = time.Now()
+	s.store.Put(todo)
+	s.notify(events.TypeTodoUpdated, events.TodoUpdated{Todo: *todo}, todo.ID, todo)
+	return todo, true
+}
+
+// ClearRecurrence stops a todo from spawning further occurrences, leaving
+// any already-created children untouched.
+func (s *TodoService) ClearRecurrence(id string) (*models.Todo, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	todo, exists := s.store.Get(id)
+	if !exists {
+		return nil, false
+	}
+
+	todo.RecurrenceRule = models.RecurrenceNone
+	todo.Recurrence = ""
+	todo.UpdatedAt = time.Now()
+	s.store.Put(todo)
+	s.notify(events.TypeTodoUpdated, events.TodoUpdated{Todo: *todo}, todo.ID, todo)
 	return todo, true
 }
 
-// GetUpcomingReminders returns todos with reminders in the next 24 hours
-func (s *TodoService) GetUpcomingReminders() []*models.Todo {
+// expireSnooze clears SnoozedUntil once a scheduled snooze has passed; it's
+// invoked by the Scheduler instead of a client calling Unsnooze.
+func (s *TodoService) expireSnooze(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	todo, exists := s.store.Get(id)
+	if !exists || todo.SnoozedUntil == nil {
+		return
+	}
+
+	todo.SnoozedUntil = nil
+	todo.UpdatedAt = time.Now()
+	s.store.Put(todo)
+	s.notify(events.TypeTodoSnoozed, events.TodoSnoozed{ID: todo.ID, Until: nil}, todo.ID, todo)
+}
+
+// dispatchReminder publishes a reminder-due notification to SSE subscribers
+// when the scheduler's heap says one is due. It isn't recorded to the event
+// log since it doesn't change any todo's state.
+func (s *TodoService) dispatchReminder(id string) {
+	s.mu.RLock()
+	todo, exists := s.store.Get(id)
+	s.mu.RUnlock()
+
+	if !exists || todo.Completed {
+		return
+	}
+
+	log.Printf("reminder due: todo %s (%q)", todo.ID, todo.Text)
+	s.hub.Publish(ChangeEvent{Type: "ReminderDue", ID: todo.ID, Todo: todo, At: time.Now()})
+}
+
+// GetUpcomingReminders returns todos with a reminder firing inside window. If
+// the store is indexed (redisStore), this runs as a ZRANGEBYSCORE over the
+// reminders sorted set instead of scanning every todo.
+func (s *TodoService) GetUpcomingReminders(window models.ReminderWindow) []*models.Todo {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	now := time.Now()
-	tomorrow := now.Add(24 * time.Hour)
-	result := make([]*models.Todo, 0)
+	if indexed, ok := s.store.(indexedStore); ok {
+		ids, err := indexed.upcomingReminderIDs(window.From, window.To)
+		if err != nil {
+			return []*models.Todo{}
+		}
+		result := make([]*models.Todo, 0, len(ids))
+		for _, id := range ids {
+			todo, ok := s.store.Get(id)
+			if !ok || !includeReminder(todo, window) {
+				continue
+			}
+			if _, ok := s.dueReminderInWindow(todo, window); !ok {
+				continue
+			}
+			result = append(result, todo)
+		}
+		if window.IncludeSnoozed {
+			result = appendSnoozedInWindow(result, s.store, window)
+		}
+		return result
+	}
 
-	for _, todo := range s.todos {
-		if todo.Completed || todo.DueDate == nil || todo.ReminderTime == nil {
-			continue
+	result := make([]*models.Todo, 0)
+	s.store.Range(func(todo *models.Todo) bool {
+		if todo.Completed {
+			return true
 		}
 
-		// Parse due date and reminder time
-		dueDate, err := time.Parse("2006-01-02", *todo.DueDate)
-		if err != nil {
-			continue
+		if window.IncludeSnoozed && todo.SnoozedUntil != nil {
+			if !todo.SnoozedUntil.Before(window.From) && todo.SnoozedUntil.Before(window.To) {
+				result = append(result, todo)
+				return true
+			}
 		}
 
-		parts := strings.Split(*todo.ReminderTime, ":")
-		if len(parts) != 2 {
-			continue
+		if _, ok := s.dueReminderInWindow(todo, window); ok {
+			result = append(result, todo)
 		}
+		return true
+	})
 
-		hour, err1 := strconv.Atoi(parts[0])
-		minute, err2 := strconv.Atoi(parts[1])
-		if err1 != nil || err2 != nil {
-			continue
+	return result
+}
+
+// dueReminderInWindow returns the first of todo's reminder fire times that
+// falls strictly inside window and isn't suppressed by an active
+// maintenance window, or ok=false if none qualifies.
+func (s *TodoService) dueReminderInWindow(todo *models.Todo, window models.ReminderWindow) (time.Time, bool) {
+	for _, fireAt := range reminderFireTimes(todo) {
+		if fireAt.After(window.From) && fireAt.Before(window.To) && !s.reminderSuppressed(todo, fireAt) {
+			return fireAt, true
 		}
+	}
+	return time.Time{}, false
+}
+
+// reminderSuppressed reports whether fireAt (a todo's computed reminder
+// time) falls inside an active maintenance window, in which case
+// GetUpcomingReminders should skip todo rather than surface it.
+func (s *TodoService) reminderSuppressed(todo *models.Todo, fireAt time.Time) bool {
+	return s.maintenance != nil && s.maintenance.IsActive(fireAt, todo)
+}
 
-		reminderTime := time.Date(
-			dueDate.Year(), dueDate.Month(), dueDate.Day(),
-			hour, minute, 0, 0, dueDate.Location(),
-		)
+// includeReminder reports whether an indexed-store hit (already known to
+// have a reminder in the window) should be returned: uncompleted, or
+// completed-but-snoozed-into-the-window when IncludeSnoozed is set.
+func includeReminder(todo *models.Todo, window models.ReminderWindow) bool {
+	if !todo.Completed {
+		return true
+	}
+	return window.IncludeSnoozed && todo.SnoozedUntil != nil &&
+		!todo.SnoozedUntil.Before(window.From) && todo.SnoozedUntil.Before(window.To)
+}
 
-		// Check if reminder is within next 24 hours
-		if reminderTime.After(now) && reminderTime.Before(tomorrow) {
+// appendSnoozedInWindow adds todos whose SnoozedUntil (rather than their
+// regular reminder) falls inside window, for indexed stores whose
+// upcomingReminderIDs only covers the reminder-time sorted set.
+func appendSnoozedInWindow(result []*models.Todo, store TodoStore, window models.ReminderWindow) []*models.Todo {
+	seen := make(map[string]bool, len(result))
+	for _, todo := range result {
+		seen[todo.ID] = true
+	}
+
+	store.Range(func(todo *models.Todo) bool {
+		if seen[todo.ID] || todo.SnoozedUntil == nil {
+			return true
+		}
+		if !todo.SnoozedUntil.Before(window.From) && todo.SnoozedUntil.Before(window.To) {
 			result = append(result, todo)
 		}
+		return true
+	})
+	return result
+}
+
+// anchorDate resolves a relative Reminder's anchor ("dueDate" or
+// "startDate") to todo's corresponding date field, or ok=false if that
+// field isn't set or fails to parse.
+func anchorDate(todo *models.Todo, anchor models.ReminderAnchor) (time.Time, bool) {
+	raw := todo.DueDate
+	if anchor == models.ReminderAnchorStartDate {
+		raw = todo.StartDate
+	}
+	if raw == nil {
+		return time.Time{}, false
+	}
+	t, err := time.Parse("2006-01-02", *raw)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// reminderFireTime computes the instant a single Reminder fires: its own At
+// if set, otherwise its anchor date plus OffsetSeconds. ok is false for a
+// relative reminder whose anchor date isn't set (or fails to parse).
+func reminderFireTime(todo *models.Todo, reminder models.Reminder) (time.Time, bool) {
+	if reminder.At != nil {
+		return *reminder.At, true
 	}
 
-	return result
+	anchor, ok := anchorDate(todo, reminder.RelativeTo)
+	if !ok {
+		return time.Time{}, false
+	}
+	return anchor.Add(time.Duration(reminder.OffsetSeconds) * time.Second), true
+}
+
+// reminderFireTimes computes the fire time for each of todo's reminders,
+// skipping any relative reminder whose anchor date isn't set.
+func reminderFireTimes(todo *models.Todo) []time.Time {
+	times := make([]time.Time, 0, len(todo.Reminders))
+	for _, r := range todo.Reminders {
+		if fireAt, ok := reminderFireTime(todo, r); ok {
+			times = append(times, fireAt)
+		}
+	}
+	return times
+}
+
+// earliestReminderFireTime returns the soonest of todo's reminder fire
+// times, or ok=false if it has none due.
+func earliestReminderFireTime(todo *models.Todo) (time.Time, bool) {
+	earliest := time.Time{}
+	found := false
+	for _, t := range reminderFireTimes(todo) {
+		if !found || t.Before(earliest) {
+			earliest = t
+			found = true
+		}
+	}
+	return earliest, found
+}
+
+// ValidateReminders checks that every relative reminder in reminders has an
+// anchor date available: dueDate for ReminderAnchorDueDate, startDate for
+// ReminderAnchorStartDate. For an update, pass the todo's effective
+// (post-update) dueDate/startDate, not necessarily the values in the
+// request body alone. Returns a descriptive error naming the first invalid
+// reminder, or nil if every reminder is valid.
+func ValidateReminders(reminders []models.Reminder, dueDate, startDate *string) error {
+	for i, r := range reminders {
+		if r.At != nil {
+			continue
+		}
+		switch r.RelativeTo {
+		case models.ReminderAnchorDueDate:
+			if dueDate == nil {
+				return fmt.Errorf("reminder %d is relative to dueDate, but the todo has no due date", i)
+			}
+		case models.ReminderAnchorStartDate:
+			if startDate == nil {
+				return fmt.Errorf("reminder %d is relative to startDate, but the todo has no start date", i)
+			}
+		default:
+			return fmt.Errorf("reminder %d has neither an absolute time nor a recognized relativeTo anchor", i)
+		}
+	}
+	return nil
 }
 
 // ProcessRecurringTodos handles recurring todo logic
@@ -87,100 +316,170 @@ func (s *TodoService) ProcessRecurringTodos() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	for _, todo := range s.todos {
+	var due []*models.Todo
+	s.store.Range(func(todo *models.Todo) bool {
 		if todo.Completed && todo.RecurrenceRule != models.RecurrenceNone {
-			// Create next occurrence
-			s.createNextOccurrence(todo)
+			due = append(due, todo)
 		}
+		return true
+	})
+
+	for _, todo := range due {
+		s.createNextOccurrence(todo)
 	}
 }
 
-func (s *TodoService) createNextOccurrence(todo *models.Todo) {
+// MaterializeNext computes todo's next occurrence (see NextOccurrence) and,
+// if one exists and isn't deferred by an active maintenance window,
+// persists it as a new todo the same way ProcessRecurringTodos does.
+// Returns the new todo, or nil/false if there's nothing to materialize yet.
+func (s *TodoService) MaterializeNext(todo *models.Todo) (*models.Todo, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.createNextOccurrence(todo)
+}
+
+func (s *TodoService) createNextOccurrence(todo *models.Todo) (*models.Todo, bool) {
 	if todo.DueDate == nil {
-		return
+		return nil, false
 	}
 
 	dueDate, err := time.Parse("2006-01-02", *todo.DueDate)
 	if err != nil {
-		return
+		return nil, false
 	}
 
-	var nextDueDate time.Time
-	switch todo.RecurrenceRule {
-	case models.RecurrenceDaily:
-		nextDueDate = dueDate.AddDate(0, 0, 1)
-	case models.RecurrenceWeekly:
-		nextDueDate = dueDate.AddDate(0, 0, 7)
-	case models.RecurrenceMonthly:
-		nextDueDate = dueDate.AddDate(0, 1, 0)
-	default:
-		return
+	rruleStr, ok := effectiveRRule(todo)
+	if !ok {
+		return nil, false
+	}
+	rule, err := recurrence.Parse(rruleStr)
+	if err != nil {
+		return nil, false
+	}
+	nextDueDate, ok := nextOccurrence(rule, dueDate, dueDate, todo)
+	if !ok {
+		return nil, false
+	}
+
+	// A maintenance window covering the next occurrence defers materializing
+	// it: ProcessRecurringTodos will retry on its next sweep, still aiming
+	// at this same (by-then overdue) occurrence rather than skipping ahead,
+	// so only a single catch-up occurrence is created once the window closes.
+	if s.maintenance != nil && s.maintenance.IsActive(nextDueDate, todo) {
+		return nil, false
 	}
 
 	nextDueDateStr := nextDueDate.Format("2006-01-02")
 	now := time.Now()
+	parentID := todo.ID
 
 	newTodo := &models.Todo{
-		ID:             uuid.New().String(),
-		Text:           todo.Text,
-		Priority:       todo.Priority,
-		Completed:      false,
-		DueDate:        &nextDueDateStr,
-		ReminderTime:   todo.ReminderTime,
-		RecurrenceRule: todo.RecurrenceRule,
-		Tags:           todo.Tags,
-		Category:       todo.Category,
-		CreatedAt:      now,
-		UpdatedAt:      now,
-	}
-
-	s.todos[newTodo.ID] = newTodo
+		ID:                 uuid.New().String(),
+		Text:               todo.Text,
+		Priority:           todo.Priority,
+		Completed:          false,
+		DueDate:            &nextDueDateStr,
+		StartDate:          todo.StartDate,
+		Reminders:          todo.Reminders,
+		RecurrenceRule:     todo.RecurrenceRule,
+		Recurrence:         todo.Recurrence,
+		RecurrenceParentID: &parentID,
+		ExceptionDates:     todo.ExceptionDates,
+		Tags:               todo.Tags,
+		Category:           todo.Category,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+
+	s.store.Put(newTodo)
+	s.notify(events.TypeTodoCreated, events.TodoCreated{Todo: *newTodo}, newTodo.ID, newTodo)
+	if s.searchIndex != nil {
+		s.searchIndex.indexTodo(newTodo)
+	}
+	if s.scheduler != nil {
+		s.scheduler.syncTodo(newTodo)
+	}
+	return newTodo, true
 }
 
-// GetByTag returns todos with a specific tag
+// GetByTag returns todos with a specific tag. If the store is indexed
+// (redisStore), this is an O(k) set lookup instead of a full scan.
 func (s *TodoService) GetByTag(tag string) []*models.Todo {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	if indexed, ok := s.store.(indexedStore); ok {
+		ids, err := indexed.idsByTag(tag)
+		if err != nil {
+			return []*models.Todo{}
+		}
+		return s.todosByIDs(ids)
+	}
+
 	result := make([]*models.Todo, 0)
-	for _, todo := range s.todos {
+	s.store.Range(func(todo *models.Todo) bool {
 		for _, t := range todo.Tags {
 			if t == tag {
 				result = append(result, todo)
 				break
 			}
 		}
-	}
+		return true
+	})
 
 	return result
 }
 
-// GetByCategory returns todos in a specific category
+// GetByCategory returns todos in a specific category. If the store is
+// indexed (redisStore), this is an O(k) set lookup instead of a full scan.
 func (s *TodoService) GetByCategory(category string) []*models.Todo {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	if indexed, ok := s.store.(indexedStore); ok {
+		ids, err := indexed.idsByCategory(category)
+		if err != nil {
+			return []*models.Todo{}
+		}
+		return s.todosByIDs(ids)
+	}
+
 	result := make([]*models.Todo, 0)
-	for _, todo := range s.todos {
+	s.store.Range(func(todo *models.Todo) bool {
 		if todo.Category != nil && *todo.Category == category {
 			result = append(result, todo)
 		}
-	}
+		return true
+	})
 
 	return result
 }
 
+// todosByIDs resolves a list of IDs (e.g. from an indexedStore set lookup)
+// back to their todos, silently skipping any that no longer exist.
+func (s *TodoService) todosByIDs(ids []string) []*models.Todo {
+	result := make([]*models.Todo, 0, len(ids))
+	for _, id := range ids {
+		if todo, ok := s.store.Get(id); ok {
+			result = append(result, todo)
+		}
+	}
+	return result
+}
+
 // GetAllTags returns all unique tags
 func (s *TodoService) GetAllTags() []string {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	tagSet := make(map[string]bool)
-	for _, todo := range s.todos {
+	s.store.Range(func(todo *models.Todo) bool {
 		for _, tag := range todo.Tags {
 			tagSet[tag] = true
 		}
-	}
+		return true
+	})
 
 	tags := make([]string, 0, len(tagSet))
 	for tag := range tagSet {
@@ -196,11 +495,12 @@ func (s *TodoService) GetAllCategories() []string {
 	defer s.mu.RUnlock()
 
 	categorySet := make(map[string]bool)
-	for _, todo := range s.todos {
+	s.store.Range(func(todo *models.Todo) bool {
 		if todo.Category != nil {
 			categorySet[*todo.Category] = true
 		}
-	}
+		return true
+	})
 
 	categories := make([]string, 0, len(categorySet))
 	for cat := range categorySet {
@@ -210,64 +510,120 @@ func (s *TodoService) GetAllCategories() []string {
 	return categories
 }
 
-// BulkDelete deletes multiple todos
-func (s *TodoService) BulkDelete(ids []string) int {
+// BulkDelete deletes multiple todos. It aborts between items if ctx is
+// cancelled (e.g. the client disconnected or the request deadline middleware
+// fired), returning the count affected so far and cancelled=true.
+func (s *TodoService) BulkDelete(ctx context.Context, ids []string) (affected int, cancelled bool) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	count := 0
-	for _, id := range ids {
-		if _, exists := s.todos[id]; exists {
-			delete(s.todos, id)
-			count++
+	s.notify(events.TypeBulkOp, events.BulkOp{Operation: "delete", IDs: ids}, "", nil)
+
+	s.store.Txn(func(tx TodoStore) error {
+		for _, id := range ids {
+			select {
+			case <-ctx.Done():
+				cancelled = true
+				return nil
+			default:
+			}
+
+			if todo, exists := tx.Delete(id); exists {
+				if s.searchIndex != nil {
+					s.searchIndex.removeTodo(todo)
+				}
+				affected++
+			}
 		}
-	}
+		return nil
+	})
 
-	return count
+	return affected, cancelled
 }
 
-// BulkComplete marks multiple todos as completed
-func (s *TodoService) BulkComplete(ids []string, completed bool) int {
+// BulkComplete marks multiple todos as completed, aborting between items if
+// ctx is cancelled.
+func (s *TodoService) BulkComplete(ctx context.Context, ids []string, completed bool) (affected int, cancelled bool) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	count := 0
-	for _, id := range ids {
-		if todo, exists := s.todos[id]; exists {
-			todo.Completed = completed
-			todo.UpdatedAt = time.Now()
-			
-			// Handle recurring todos
-			if completed && todo.RecurrenceRule != models.RecurrenceNone {
-				s.createNextOccurrence(todo)
+	operation := "uncomplete"
+	if completed {
+		operation = "complete"
+	}
+	s.notify(events.TypeBulkOp, events.BulkOp{Operation: operation, IDs: ids}, "", nil)
+
+	var toRecur []*models.Todo
+	s.store.Txn(func(tx TodoStore) error {
+		for _, id := range ids {
+			select {
+			case <-ctx.Done():
+				cancelled = true
+				return nil
+			default:
+			}
+
+			if todo, exists := tx.Get(id); exists {
+				todo.Completed = completed
+				todo.UpdatedAt = time.Now()
+				tx.Put(todo)
+
+				// Handle recurring todos
+				if completed && todo.RecurrenceRule != models.RecurrenceNone {
+					toRecur = append(toRecur, todo)
+				}
+
+				affected++
 			}
-			
-			count++
 		}
+		return nil
+	})
+
+	for _, todo := range toRecur {
+		s.createNextOccurrence(todo)
 	}
 
-	return count
+	return affected, cancelled
 }
 
-// BulkUpdatePriority updates priority for multiple todos
-func (s *TodoService) BulkUpdatePriority(ids []string, priority models.Priority) int {
+// BulkUpdatePriority updates priority for multiple todos, aborting between
+// items if ctx is cancelled.
+func (s *TodoService) BulkUpdatePriority(ctx context.Context, ids []string, priority models.Priority) (affected int, cancelled bool) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	count := 0
-	for _, id := range ids {
-		if todo, exists := s.todos[id]; exists {
-			todo.Priority = priority
-			todo.UpdatedAt = time.Now()
-			count++
+	s.notify(events.TypeBulkOp, events.BulkOp{Operation: "updatePriority", IDs: ids, Priority: string(priority)}, "", nil)
+
+	s.store.Txn(func(tx TodoStore) error {
+		for _, id := range ids {
+			select {
+			case <-ctx.Done():
+				cancelled = true
+				return nil
+			default:
+			}
+
+			if todo, exists := tx.Get(id); exists {
+				oldPriority := todo.Priority
+				todo.Priority = priority
+				todo.UpdatedAt = time.Now()
+				tx.Put(todo)
+				if s.searchIndex != nil && oldPriority != priority {
+					s.searchIndex.remove("priority", strings.ToLower(string(oldPriority)), id)
+					s.searchIndex.add("priority", strings.ToLower(string(priority)), id)
+				}
+				affected++
+			}
 		}
-	}
+		return nil
+	})
 
-	return count
+	return affected, cancelled
 }
 
-// ImportTodos imports todos from a slice
-func (s *TodoService) ImportTodos(todos []models.TodoCreate, mode string) models.ImportResult {
+// ImportTodos imports todos from a slice, aborting between rows if ctx is
+// cancelled; ImportResult.Cancelled reports whether that happened.
+func (s *TodoService) ImportTodos(ctx context.Context, todos []models.TodoCreate, mode string) models.ImportResult {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -281,10 +637,21 @@ func (s *TodoService) ImportTodos(todos []models.TodoCreate, mode string) models
 
 	// Replace mode: clear existing todos
 	if mode == "replace" {
-		s.todos = make(map[string]*models.Todo)
+		s.notify(events.TypeBulkOp, events.BulkOp{Operation: "clear"}, "", nil)
+		resetStore(s.store)
+		if s.searchIndex != nil {
+			s.searchIndex = newSearchIndex()
+		}
 	}
 
 	for i, todoCreate := range todos {
+		select {
+		case <-ctx.Done():
+			result.Cancelled = true
+			return result
+		default:
+		}
+
 		// Validate
 		if todoCreate.Text == "" {
 			result.Errors = append(result.Errors, "Row "+strconv.Itoa(i+1)+": Text is required")
@@ -313,7 +680,8 @@ func (s *TodoService) ImportTodos(todos []models.TodoCreate, mode string) models
 			Priority:       todoCreate.Priority,
 			Completed:      todoCreate.Completed,
 			DueDate:        todoCreate.DueDate,
-			ReminderTime:   todoCreate.ReminderTime,
+			StartDate:      todoCreate.StartDate,
+			Reminders:      todoCreate.Reminders,
 			RecurrenceRule: todoCreate.RecurrenceRule,
 			Tags:           todoCreate.Tags,
 			Category:       todoCreate.Category,
@@ -321,7 +689,11 @@ func (s *TodoService) ImportTodos(todos []models.TodoCreate, mode string) models
 			UpdatedAt:      now,
 		}
 
-		s.todos[todo.ID] = todo
+		s.notify(events.TypeTodoCreated, events.TodoCreated{Todo: *todo}, todo.ID, todo)
+		s.store.Put(todo)
+		if s.searchIndex != nil {
+			s.searchIndex.indexTodo(todo)
+		}
 		result.Imported++
 	}
 
@@ -334,17 +706,18 @@ func (s *TodoService) ExportTodos(filter string) models.ExportResult {
 	defer s.mu.RUnlock()
 
 	todos := make([]models.Todo, 0)
-	for _, todo := range s.todos {
+	s.store.Range(func(todo *models.Todo) bool {
 		// Apply filter
 		if filter == "active" && todo.Completed {
-			continue
+			return true
 		}
 		if filter == "completed" && !todo.Completed {
-			continue
+			return true
 		}
-		
+
 		todos = append(todos, *todo)
-	}
+		return true
+	})
 
 	return models.ExportResult{
 		Data:       todos,
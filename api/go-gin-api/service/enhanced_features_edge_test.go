@@ -1,14 +1,14 @@
 package service
 
 import (
+	"context"
 	"spicytodo-go-api/models"
 	"testing"
 	"time"
 )
 
 func TestSnoozeInThePast(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	todo := service.Create(models.TodoCreate{Text: "Test"})
 	
@@ -29,7 +29,7 @@ func TestSnoozeInThePast(t *testing.T) {
 }
 
 func TestSnoozeNonExistentTodo(t *testing.T) {
-	service := NewTodoService()
+	service := newIsolatedTodoService(t)
 	
 	until := time.Now().Add(2 * time.Hour)
 	_, exists := service.Snooze("non-existent", until)
@@ -40,7 +40,7 @@ func TestSnoozeNonExistentTodo(t *testing.T) {
 }
 
 func TestUnsnoozeNonExistentTodo(t *testing.T) {
-	service := NewTodoService()
+	service := newIsolatedTodoService(t)
 	
 	_, exists := service.Unsnooze("non-existent")
 
@@ -50,8 +50,7 @@ func TestUnsnoozeNonExistentTodo(t *testing.T) {
 }
 
 func TestUnsnoozeAlreadyUnsnoozed(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	todo := service.Create(models.TodoCreate{Text: "Test"})
 	
@@ -64,54 +63,51 @@ func TestUnsnoozeAlreadyUnsnoozed(t *testing.T) {
 }
 
 func TestGetUpcomingRemindersWithNoReminders(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	service.Create(models.TodoCreate{Text: "No reminder"})
 
-	reminders := service.GetUpcomingReminders()
+	reminders := service.GetUpcomingReminders(defaultReminderWindow(t))
 	
 	if len(reminders) != 0 {
 		t.Errorf("Expected 0 reminders, got %d", len(reminders))
 	}
 }
 
-func TestGetUpcomingRemindersWithInvalidTime(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+func TestGetUpcomingRemindersWithUnresolvableAnchor(t *testing.T) {
+	service := newTestServiceWithEvents(t)
 
 	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
-	invalidTime := "25:99" // Invalid time
 
 	service.Create(models.TodoCreate{
-		Text:         "Invalid Time",
-		DueDate:      &tomorrow,
-		ReminderTime: &invalidTime,
+		Text:    "Relative To Missing Anchor",
+		DueDate: &tomorrow,
+		// Relative to startDate, but the todo has no start date, so this
+		// reminder has no fire time to compute.
+		Reminders: []models.Reminder{{RelativeTo: models.ReminderAnchorStartDate, OffsetSeconds: -3600}},
 	})
 
-	reminders := service.GetUpcomingReminders()
-	
-	// Should skip todos with invalid times
+	reminders := service.GetUpcomingReminders(defaultReminderWindow(t))
+
+	// Should skip todos whose reminders have no resolvable fire time
 	if len(reminders) != 0 {
-		t.Error("Should skip todos with invalid reminder times")
+		t.Error("Should skip todos with unresolvable reminder anchors")
 	}
 }
 
 func TestGetUpcomingRemindersExcludesCompleted(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	tomorrow := time.Now().Add(12 * time.Hour).Format("2006-01-02")
-	reminderTime := "10:00"
 
 	service.Create(models.TodoCreate{
-		Text:         "Completed Todo",
-		DueDate:      &tomorrow,
-		ReminderTime: &reminderTime,
-		Completed:    true,
+		Text:      "Completed Todo",
+		DueDate:   &tomorrow,
+		Reminders: []models.Reminder{{RelativeTo: models.ReminderAnchorDueDate, OffsetSeconds: 10 * 3600}},
+		Completed: true,
 	})
 
-	reminders := service.GetUpcomingReminders()
+	reminders := service.GetUpcomingReminders(defaultReminderWindow(t))
 	
 	if len(reminders) != 0 {
 		t.Error("Should not include completed todos in reminders")
@@ -119,8 +115,7 @@ func TestGetUpcomingRemindersExcludesCompleted(t *testing.T) {
 }
 
 func TestProcessRecurringTodosWithNoDueDate(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	todo := service.Create(models.TodoCreate{
 		Text:           "No Due Date",
@@ -128,18 +123,191 @@ func TestProcessRecurringTodosWithNoDueDate(t *testing.T) {
 		Completed:      true,
 	})
 
-	initialCount := len(service.todos)
+	initialCount := storeLen(service.store)
 	service.ProcessRecurringTodos()
 
 	// Should not create new occurrence without due date
-	if len(service.todos) != initialCount {
+	if storeLen(service.store) != initialCount {
 		t.Error("Should not create occurrence for recurring todo without due date")
 	}
 }
 
+func TestProcessRecurringTodosByDay(t *testing.T) {
+	service := newTestServiceWithEvents(t)
+
+	dueDate := "2026-01-05" // a Monday
+
+	todo := service.Create(models.TodoCreate{
+		Text:           "Standup",
+		RecurrenceRule: models.RecurrenceWeekly,
+		Recurrence:     "FREQ=WEEKLY;BYDAY=MO,WE,FR",
+		DueDate:        &dueDate,
+		Completed:      true,
+	})
+
+	service.ProcessRecurringTodos()
+
+	children := childrenOf(service, todo.ID)
+	if len(children) != 1 {
+		t.Fatalf("expected exactly 1 next occurrence, got %d", len(children))
+	}
+	if *children[0].DueDate != "2026-01-07" {
+		t.Errorf("expected next BYDAY occurrence to land on Wednesday 2026-01-07, got %s", *children[0].DueDate)
+	}
+}
+
+func TestProcessRecurringTodosIntervalTwo(t *testing.T) {
+	service := newTestServiceWithEvents(t)
+
+	dueDate := "2026-01-05"
+
+	todo := service.Create(models.TodoCreate{
+		Text:           "Every other day",
+		RecurrenceRule: models.RecurrenceDaily,
+		Recurrence:     "FREQ=DAILY;INTERVAL=2",
+		DueDate:        &dueDate,
+		Completed:      true,
+	})
+
+	service.ProcessRecurringTodos()
+
+	children := childrenOf(service, todo.ID)
+	if len(children) != 1 {
+		t.Fatalf("expected exactly 1 next occurrence, got %d", len(children))
+	}
+	if *children[0].DueDate != "2026-01-07" {
+		t.Errorf("expected INTERVAL=2 to skip a day to 2026-01-07, got %s", *children[0].DueDate)
+	}
+}
+
+func TestProcessRecurringTodosCountExhausted(t *testing.T) {
+	service := newTestServiceWithEvents(t)
+
+	dueDate := "2026-01-05"
+
+	todo := service.Create(models.TodoCreate{
+		Text:           "One shot",
+		RecurrenceRule: models.RecurrenceDaily,
+		Recurrence:     "FREQ=DAILY;COUNT=1",
+		DueDate:        &dueDate,
+		Completed:      true,
+	})
+
+	initialCount := storeLen(service.store)
+	service.ProcessRecurringTodos()
+
+	// The due date is itself the rule's only occurrence, so no further
+	// occurrence should ever be materialized.
+	if storeLen(service.store) != initialCount {
+		t.Error("Should not create an occurrence once COUNT is exhausted")
+	}
+	if len(childrenOf(service, todo.ID)) != 0 {
+		t.Error("Should not create a child todo once COUNT is exhausted")
+	}
+}
+
+func TestProcessRecurringTodosUntilInPast(t *testing.T) {
+	service := newTestServiceWithEvents(t)
+
+	dueDate := "2026-01-05"
+
+	todo := service.Create(models.TodoCreate{
+		Text:           "Expired",
+		RecurrenceRule: models.RecurrenceDaily,
+		Recurrence:     "FREQ=DAILY;UNTIL=20260105T000000Z",
+		DueDate:        &dueDate,
+		Completed:      true,
+	})
+
+	initialCount := storeLen(service.store)
+	service.ProcessRecurringTodos()
+
+	// UNTIL falls before the next would-be occurrence, so the series has
+	// already ended and no child should be created.
+	if storeLen(service.store) != initialCount {
+		t.Error("Should not create an occurrence once UNTIL has passed")
+	}
+	if len(childrenOf(service, todo.ID)) != 0 {
+		t.Error("Should not create a child todo once UNTIL has passed")
+	}
+}
+
+func TestProcessRecurringTodosSkipsExceptionDate(t *testing.T) {
+	service := newTestServiceWithEvents(t)
+
+	dueDate := "2026-01-05" // a Monday
+
+	todo := service.Create(models.TodoCreate{
+		Text:           "Standup",
+		RecurrenceRule: models.RecurrenceWeekly,
+		Recurrence:     "FREQ=WEEKLY;BYDAY=MO,WE",
+		DueDate:        &dueDate,
+		ExceptionDates: []string{"2026-01-07"},
+		Completed:      true,
+	})
+
+	service.ProcessRecurringTodos()
+
+	children := childrenOf(service, todo.ID)
+	if len(children) != 1 {
+		t.Fatalf("expected exactly 1 next occurrence, got %d", len(children))
+	}
+	if *children[0].DueDate != "2026-01-12" {
+		t.Errorf("expected the excepted Wednesday to be skipped in favor of 2026-01-12, got %s", *children[0].DueDate)
+	}
+}
+
+func TestMaterializeNextCreatesOccurrence(t *testing.T) {
+	service := newTestServiceWithEvents(t)
+
+	dueDate := "2026-01-05"
+	todo := service.Create(models.TodoCreate{
+		Text:           "Standup",
+		RecurrenceRule: models.RecurrenceDaily,
+		Recurrence:     "FREQ=DAILY",
+		DueDate:        &dueDate,
+	})
+
+	next, ok := service.MaterializeNext(todo)
+	if !ok {
+		t.Fatal("expected a materialized next occurrence")
+	}
+	if *next.DueDate != "2026-01-06" {
+		t.Errorf("expected next due date 2026-01-06, got %s", *next.DueDate)
+	}
+	if next.RecurrenceParentID == nil || *next.RecurrenceParentID != todo.ID {
+		t.Error("expected the new occurrence to point back at its parent")
+	}
+}
+
+func TestMaterializeNextWithoutRecurrenceReturnsFalse(t *testing.T) {
+	service := newTestServiceWithEvents(t)
+
+	dueDate := "2026-01-05"
+	todo := service.Create(models.TodoCreate{
+		Text:    "One-off",
+		DueDate: &dueDate,
+	})
+
+	if _, ok := service.MaterializeNext(todo); ok {
+		t.Error("expected no next occurrence for a non-recurring todo")
+	}
+}
+
+// childrenOf returns the todos recorded with parentID as their
+// RecurrenceParentID, for asserting on freshly materialized occurrences.
+func childrenOf(service *TodoService, parentID string) []*models.Todo {
+	var children []*models.Todo
+	for _, todo := range service.GetAll("", "", "") {
+		if todo.RecurrenceParentID != nil && *todo.RecurrenceParentID == parentID {
+			children = append(children, todo)
+		}
+	}
+	return children
+}
+
 func TestGetByTagWithNoTags(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	service.Create(models.TodoCreate{Text: "No Tags"})
 
@@ -151,8 +319,7 @@ func TestGetByTagWithNoTags(t *testing.T) {
 }
 
 func TestGetByTagWithMultipleTags(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	service.Create(models.TodoCreate{
 		Text: "Multi Tag",
@@ -170,8 +337,7 @@ func TestGetByTagWithMultipleTags(t *testing.T) {
 }
 
 func TestGetByCategoryWithNoCategory(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	service.Create(models.TodoCreate{Text: "No Category"})
 
@@ -183,8 +349,7 @@ func TestGetByCategoryWithNoCategory(t *testing.T) {
 }
 
 func TestGetAllTagsWithDuplicates(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	service.Create(models.TodoCreate{Text: "Todo 1", Tags: []string{"work", "urgent"}})
 	service.Create(models.TodoCreate{Text: "Todo 2", Tags: []string{"work", "meeting"}})
@@ -211,13 +376,12 @@ func TestGetAllTagsWithDuplicates(t *testing.T) {
 }
 
 func TestBulkDeleteWithSomeNonExistent(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	todo1 := service.Create(models.TodoCreate{Text: "Exists"})
 	
 	ids := []string{todo1.ID, "non-existent-1", "non-existent-2"}
-	affected := service.BulkDelete(ids)
+	affected, _ := service.BulkDelete(context.Background(), ids)
 
 	if affected != 1 {
 		t.Errorf("Expected 1 deleted, got %d", affected)
@@ -225,9 +389,9 @@ func TestBulkDeleteWithSomeNonExistent(t *testing.T) {
 }
 
 func TestBulkDeleteEmptyList(t *testing.T) {
-	service := NewTodoService()
+	service := newIsolatedTodoService(t)
 	
-	affected := service.BulkDelete([]string{})
+	affected, _ := service.BulkDelete(context.Background(), []string{})
 
 	if affected != 0 {
 		t.Errorf("Expected 0 deleted for empty list, got %d", affected)
@@ -235,8 +399,7 @@ func TestBulkDeleteEmptyList(t *testing.T) {
 }
 
 func TestBulkCompleteWithRecurrence(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	dueDate := time.Now().Format("2006-01-02")
 	
@@ -246,21 +409,20 @@ func TestBulkCompleteWithRecurrence(t *testing.T) {
 		DueDate:        &dueDate,
 	})
 
-	initialCount := len(service.todos)
+	initialCount := storeLen(service.store)
 	
-	service.BulkComplete([]string{todo.ID}, true)
+	service.BulkComplete(context.Background(), []string{todo.ID}, true)
 
 	// Should have created new occurrence
-	if len(service.todos) <= initialCount {
+	if storeLen(service.store) <= initialCount {
 		t.Error("Expected new occurrence for recurring todo")
 	}
 }
 
 func TestImportTodosWithEmptyList(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
-	result := service.ImportTodos([]models.TodoCreate{}, "append")
+	result := service.ImportTodos(context.Background(), []models.TodoCreate{}, "append")
 
 	if result.Imported != 0 {
 		t.Errorf("Expected 0 imported, got %d", result.Imported)
@@ -271,15 +433,14 @@ func TestImportTodosWithEmptyList(t *testing.T) {
 }
 
 func TestImportTodosWithAllInvalid(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	todosToImport := []models.TodoCreate{
 		{Text: ""},                         // Empty text
 		{Text: string(make([]byte, 600))}, // Too long
 	}
 
-	result := service.ImportTodos(todosToImport, "append")
+	result := service.ImportTodos(context.Background(), todosToImport, "append")
 
 	if result.Imported != 0 {
 		t.Errorf("Expected 0 imported, got %d", result.Imported)
@@ -293,14 +454,14 @@ func TestImportTodosWithAllInvalid(t *testing.T) {
 }
 
 func TestImportTodosInvalidMode(t *testing.T) {
-	service := NewTodoService()
+	service := newIsolatedTodoService(t)
 	
 	todosToImport := []models.TodoCreate{
 		{Text: "Test"},
 	}
 
 	// Mode validation happens in handler, but test service behavior
-	result := service.ImportTodos(todosToImport, "invalid-mode")
+	result := service.ImportTodos(context.Background(), todosToImport, "invalid-mode")
 
 	// Service should still process (handler validates mode)
 	if result.Imported != 1 {
@@ -309,8 +470,7 @@ func TestImportTodosInvalidMode(t *testing.T) {
 }
 
 func TestExportTodosWithEmptyService(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	result := service.ExportTodos("all")
 
@@ -323,8 +483,7 @@ func TestExportTodosWithEmptyService(t *testing.T) {
 }
 
 func TestGetAllCategoriesWithNoCategories(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	service.Create(models.TodoCreate{Text: "No Category"})
 
@@ -336,13 +495,12 @@ func TestGetAllCategoriesWithNoCategories(t *testing.T) {
 }
 
 func TestBulkUpdatePriorityWithNonExistent(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	todo := service.Create(models.TodoCreate{Text: "Exists"})
 	
 	ids := []string{todo.ID, "non-existent"}
-	affected := service.BulkUpdatePriority(ids, models.PriorityHigh)
+	affected, _ := service.BulkUpdatePriority(context.Background(), ids, models.PriorityHigh)
 
 	if affected != 1 {
 		t.Errorf("Expected 1 updated, got %d", affected)
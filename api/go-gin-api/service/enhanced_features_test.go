@@ -1,14 +1,25 @@
 package service
 
 import (
+	"context"
 	"spicytodo-go-api/models"
 	"testing"
 	"time"
 )
 
+// defaultReminderWindow builds the "next 24 hours" window GetUpcomingReminders
+// used before it took an explicit ReminderWindow.
+func defaultReminderWindow(t *testing.T) models.ReminderWindow {
+	t.Helper()
+	var window models.ReminderWindow
+	if err := window.SetDefaults(time.Now()); err != nil {
+		t.Fatalf("SetDefaults: %v", err)
+	}
+	return window
+}
+
 func TestSnooze(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	created := service.Create(models.TodoCreate{Text: "Test Todo"})
 	
@@ -29,8 +40,7 @@ func TestSnooze(t *testing.T) {
 }
 
 func TestUnsnooze(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	created := service.Create(models.TodoCreate{Text: "Test Todo"})
 	until := time.Now().Add(2 * time.Hour)
@@ -48,8 +58,7 @@ func TestUnsnooze(t *testing.T) {
 }
 
 func TestGetAllFiltersSnoozed(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	// Create a normal todo and a snoozed todo
 	service.Create(models.TodoCreate{Text: "Active Todo"})
@@ -72,29 +81,32 @@ func TestGetAllFiltersSnoozed(t *testing.T) {
 }
 
 func TestGetUpcomingReminders(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	tomorrow := time.Now().Add(24 * time.Hour)
 	tomorrowStr := tomorrow.Format("2006-01-02")
 	nextWeekStr := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
-	reminderTime := "10:00"
+	reminder := []models.Reminder{{RelativeTo: models.ReminderAnchorDueDate, OffsetSeconds: 10 * 3600}}
 
 	// Create todos with different due dates
 	service.Create(models.TodoCreate{
-		Text:         "Due Tomorrow",
-		DueDate:      &tomorrowStr,
-		ReminderTime: &reminderTime,
+		Text:      "Due Tomorrow",
+		DueDate:   &tomorrowStr,
+		Reminders: reminder,
 	})
 
 	service.Create(models.TodoCreate{
-		Text:         "Due Next Week",
-		DueDate:      &nextWeekStr,
-		ReminderTime: &reminderTime,
+		Text:      "Due Next Week",
+		DueDate:   &nextWeekStr,
+		Reminders: reminder,
 	})
 
-	// Get upcoming reminders (within 24 hours)
-	reminders := service.GetUpcomingReminders()
+	// Use an explicit 48h window rather than the 24h default: the "due
+	// tomorrow" reminder fires at 10:00 on that date, which can land either
+	// side of a bare now+24h cutoff depending on what time the test runs.
+	now := time.Now()
+	window := models.ReminderWindow{From: now, To: now.Add(48 * time.Hour)}
+	reminders := service.GetUpcomingReminders(window)
 
 	if len(reminders) != 1 {
 		t.Errorf("Expected 1 upcoming reminder, got %d", len(reminders))
@@ -105,9 +117,42 @@ func TestGetUpcomingReminders(t *testing.T) {
 	}
 }
 
+func TestGetUpcomingRemindersDuringMaintenance(t *testing.T) {
+	service := newTestServiceWithEvents(t)
+
+	tomorrow := time.Now().Add(24 * time.Hour)
+	tomorrowStr := tomorrow.Format("2006-01-02")
+
+	service.Create(models.TodoCreate{
+		Text:      "Due Tomorrow",
+		DueDate:   &tomorrowStr,
+		Reminders: []models.Reminder{{RelativeTo: models.ReminderAnchorDueDate, OffsetSeconds: 10 * 3600}},
+	})
+
+	now := time.Now()
+	window := models.ReminderWindow{From: now, To: now.Add(48 * time.Hour)}
+
+	// Sanity check: without a maintenance window, the reminder is surfaced.
+	if reminders := service.GetUpcomingReminders(window); len(reminders) != 1 {
+		t.Fatalf("expected 1 upcoming reminder before any maintenance window, got %d", len(reminders))
+	}
+
+	service.CreateMaintenanceWindow(models.MaintenanceWindowCreate{
+		Name:   "Planned outage",
+		Target: models.MaintenanceTarget{All: true},
+		Schedule: models.MaintenanceSchedule{
+			Start: now,
+			End:   &[]time.Time{now.Add(72 * time.Hour)}[0],
+		},
+	})
+
+	if reminders := service.GetUpcomingReminders(window); len(reminders) != 0 {
+		t.Errorf("expected the reminder to be suppressed during an active maintenance window, got %d", len(reminders))
+	}
+}
+
 func TestRecurrenceHandling(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
 
@@ -117,7 +162,7 @@ func TestRecurrenceHandling(t *testing.T) {
 		DueDate:        &tomorrow,
 	})
 
-	initialCount := len(service.todos)
+	initialCount := storeLen(service.store)
 
 	// Toggle to completed (should trigger recurrence)
 	service.Toggle(created.ID)
@@ -126,14 +171,50 @@ func TestRecurrenceHandling(t *testing.T) {
 	service.ProcessRecurringTodos()
 
 	// Should have created a new occurrence
-	if len(service.todos) <= initialCount {
+	if storeLen(service.store) <= initialCount {
 		t.Error("Expected new todo to be created for recurring task")
 	}
 }
 
+func TestProcessRecurringTodosDefersDuringWindow(t *testing.T) {
+	service := newTestServiceWithEvents(t)
+
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+
+	created := service.Create(models.TodoCreate{
+		Text:           "Recurring Todo",
+		RecurrenceRule: models.RecurrenceDaily,
+		DueDate:        &tomorrow,
+	})
+	service.Toggle(created.ID)
+
+	initialCount := storeLen(service.store)
+
+	window := service.CreateMaintenanceWindow(models.MaintenanceWindowCreate{
+		Name:   "Planned outage",
+		Target: models.MaintenanceTarget{All: true},
+		Schedule: models.MaintenanceSchedule{
+			Start: time.Now(),
+			End:   &[]time.Time{time.Now().AddDate(0, 0, 3)}[0],
+		},
+	})
+
+	service.ProcessRecurringTodos()
+	if storeLen(service.store) != initialCount {
+		t.Error("expected next occurrence to be deferred while the maintenance window is active")
+	}
+
+	// Once the window closes, the next sweep creates exactly one catch-up
+	// occurrence for the same (by-then overdue) next due date.
+	service.DeleteMaintenanceWindow(window.ID)
+	service.ProcessRecurringTodos()
+	if storeLen(service.store) != initialCount+1 {
+		t.Errorf("expected exactly 1 catch-up occurrence after the window closed, store went from %d to %d", initialCount, storeLen(service.store))
+	}
+}
+
 func TestGetByTag(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	service.Create(models.TodoCreate{
 		Text: "Todo 1",
@@ -164,8 +245,7 @@ func TestGetByTag(t *testing.T) {
 }
 
 func TestGetByCategory(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	category1 := "Work"
 	category2 := "Personal"
@@ -193,8 +273,7 @@ func TestGetByCategory(t *testing.T) {
 }
 
 func TestGetAllTags(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	service.Create(models.TodoCreate{
 		Text: "Todo 1",
@@ -224,38 +303,36 @@ func TestGetAllTags(t *testing.T) {
 }
 
 func TestBulkDelete(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	todo1 := service.Create(models.TodoCreate{Text: "Todo 1"})
 	todo2 := service.Create(models.TodoCreate{Text: "Todo 2"})
 	todo3 := service.Create(models.TodoCreate{Text: "Todo 3"})
 
 	ids := []string{todo1.ID, todo2.ID}
-	affected := service.BulkDelete(ids)
+	affected, _ := service.BulkDelete(context.Background(), ids)
 
 	if affected != 2 {
 		t.Errorf("Expected 2 todos deleted, got %d", affected)
 	}
 
-	if len(service.todos) != 1 {
-		t.Errorf("Expected 1 todo remaining, got %d", len(service.todos))
+	if storeLen(service.store) != 1 {
+		t.Errorf("Expected 1 todo remaining, got %d", storeLen(service.store))
 	}
 
-	if _, exists := service.todos[todo3.ID]; !exists {
+	if _, exists := service.store.Get(todo3.ID); !exists {
 		t.Error("Expected todo3 to still exist")
 	}
 }
 
 func TestBulkComplete(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	todo1 := service.Create(models.TodoCreate{Text: "Todo 1", Completed: false})
 	todo2 := service.Create(models.TodoCreate{Text: "Todo 2", Completed: false})
 
 	ids := []string{todo1.ID, todo2.ID}
-	affected := service.BulkComplete(ids, true)
+	affected, _ := service.BulkComplete(context.Background(), ids, true)
 
 	if affected != 2 {
 		t.Errorf("Expected 2 todos completed, got %d", affected)
@@ -271,15 +348,14 @@ func TestBulkComplete(t *testing.T) {
 }
 
 func TestImportTodos(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	todosToImport := []models.TodoCreate{
 		{Text: "Imported 1", Priority: models.PriorityHigh},
 		{Text: "Imported 2", Priority: models.PriorityMedium},
 	}
 
-	result := service.ImportTodos(todosToImport, "append")
+	result := service.ImportTodos(context.Background(), todosToImport, "append")
 
 	if result.Imported != 2 {
 		t.Errorf("Expected 2 imported, got %d", result.Imported)
@@ -289,16 +365,16 @@ func TestImportTodos(t *testing.T) {
 		t.Errorf("Expected 0 skipped, got %d", result.Skipped)
 	}
 
-	if len(service.todos) != 2 {
-		t.Errorf("Expected 2 todos in storage, got %d", len(service.todos))
+	if storeLen(service.store) != 2 {
+		t.Errorf("Expected 2 todos in storage, got %d", storeLen(service.store))
 	}
 }
 
 func TestImportTodosReplace(t *testing.T) {
-	service := NewTodoService()
+	service := newIsolatedTodoService(t)
 	
 	// Should have sample data
-	initialCount := len(service.todos)
+	initialCount := storeLen(service.store)
 	if initialCount == 0 {
 		t.Fatal("Expected sample data to be loaded")
 	}
@@ -307,21 +383,20 @@ func TestImportTodosReplace(t *testing.T) {
 		{Text: "New Todo 1"},
 	}
 
-	result := service.ImportTodos(todosToImport, "replace")
+	result := service.ImportTodos(context.Background(), todosToImport, "replace")
 
 	if result.Imported != 1 {
 		t.Errorf("Expected 1 imported, got %d", result.Imported)
 	}
 
 	// Should have only 1 todo (replaced all)
-	if len(service.todos) != 1 {
-		t.Errorf("Expected 1 todo after replace, got %d", len(service.todos))
+	if storeLen(service.store) != 1 {
+		t.Errorf("Expected 1 todo after replace, got %d", storeLen(service.store))
 	}
 }
 
 func TestImportTodosValidation(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	todosToImport := []models.TodoCreate{
 		{Text: "Valid Todo"},
@@ -329,7 +404,7 @@ func TestImportTodosValidation(t *testing.T) {
 		{Text: string(make([]byte, 600))}, // Invalid - too long
 	}
 
-	result := service.ImportTodos(todosToImport, "append")
+	result := service.ImportTodos(context.Background(), todosToImport, "append")
 
 	if result.Imported != 1 {
 		t.Errorf("Expected 1 imported, got %d", result.Imported)
@@ -345,8 +420,7 @@ func TestImportTodosValidation(t *testing.T) {
 }
 
 func TestExportTodos(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	service.Create(models.TodoCreate{Text: "Active", Completed: false})
 	service.Create(models.TodoCreate{Text: "Completed", Completed: true})
@@ -367,8 +441,7 @@ func TestExportTodos(t *testing.T) {
 }
 
 func TestExportTodosWithFilter(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	service.Create(models.TodoCreate{Text: "Active", Completed: false})
 	service.Create(models.TodoCreate{Text: "Completed", Completed: true})
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"spicytodo-go-api/events"
+	"spicytodo-go-api/models"
+	"sync"
+	"time"
+)
+
+// snapshotThreshold is how many events accumulate in the log before it is
+// compacted into a snapshot.
+const snapshotThreshold = 100
+
+// EventStore is an append-only, newline-delimited JSON log of typed events,
+// with a companion snapshot file used to bound replay time. It is the
+// source of truth for TodoService state; the in-memory map is a read-side
+// projection rebuilt from it on startup.
+type EventStore struct {
+	mu           sync.Mutex
+	logPath      string
+	snapshotPath string
+	file         *os.File
+	seq          uint64
+	sinceSnap    int
+}
+
+// NewEventStore opens (creating if needed) the append-only log at path,
+// alongside a "<path>.snapshot" file used for compaction.
+func NewEventStore(path string) (*EventStore, error) {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EventStore{
+		logPath:      path,
+		snapshotPath: path + ".snapshot",
+		file:         file,
+	}, nil
+}
+
+// Append serializes data under the given event type and writes it as the
+// next line of the log, returning its sequence number. It reports whether
+// the log has crossed snapshotThreshold and should now be compacted via
+// WriteSnapshot.
+func (es *EventStore) Append(eventType string, data interface{}) (seq uint64, shouldSnapshot bool, err error) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return 0, false, err
+	}
+
+	es.seq++
+	record := events.Record{Seq: es.seq, Type: eventType, At: time.Now(), Data: payload}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if _, err := es.file.Write(append(line, '\n')); err != nil {
+		return 0, false, err
+	}
+
+	es.sinceSnap++
+	return es.seq, es.sinceSnap >= snapshotThreshold, nil
+}
+
+// Replay applies the snapshot (if one exists) followed by the tail of the
+// log, in order, via apply. It returns the number of records applied.
+func (es *EventStore) Replay(apply func(events.Record) error) (int, error) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	applied := 0
+
+	if snap, ok, err := es.readSnapshot(); err != nil {
+		return 0, err
+	} else if ok {
+		payload, err := json.Marshal(events.Snapshot{Todos: snap.Todos})
+		if err != nil {
+			return 0, err
+		}
+		record := events.Record{Seq: snap.Seq, Type: events.TypeSnapshot, Data: payload}
+		if err := apply(record); err != nil {
+			return 0, err
+		}
+		es.seq = snap.Seq
+		applied++
+	}
+
+	logFile, err := os.Open(es.logPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return applied, nil
+		}
+		return applied, err
+	}
+	defer logFile.Close()
+
+	scanner := bufio.NewScanner(logFile)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var record events.Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+		if err := apply(record); err != nil {
+			return applied, err
+		}
+		es.seq = record.Seq
+		es.sinceSnap++
+		applied++
+	}
+
+	return applied, scanner.Err()
+}
+
+// All returns every record a fresh replay would apply: the snapshot (if any,
+// synthesized as a Snapshot record) followed by the log tail, in order.
+// Unlike Replay it doesn't advance the store's sequence counter, so it's
+// safe to call while the service is live, e.g. to answer a history query.
+func (es *EventStore) All() ([]events.Record, error) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	var records []events.Record
+
+	if snap, ok, err := es.readSnapshot(); err != nil {
+		return nil, err
+	} else if ok {
+		payload, err := json.Marshal(events.Snapshot{Todos: snap.Todos})
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, events.Record{Seq: snap.Seq, Type: events.TypeSnapshot, Data: payload})
+	}
+
+	logFile, err := os.Open(es.logPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return records, nil
+		}
+		return nil, err
+	}
+	defer logFile.Close()
+
+	scanner := bufio.NewScanner(logFile)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var record events.Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
+
+// Since returns all log records with Seq > since, for `GET /api/events`.
+func (es *EventStore) Since(since uint64) ([]events.Record, error) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	logFile, err := os.Open(es.logPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer logFile.Close()
+
+	var result []events.Record
+	scanner := bufio.NewScanner(logFile)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var record events.Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+		if record.Seq > since {
+			result = append(result, record)
+		}
+	}
+	return result, scanner.Err()
+}
+
+// WriteSnapshot compacts the log: it writes the full state at atSeq to the
+// snapshot file, then truncates the log so future replays only need to read
+// events recorded after this point.
+func (es *EventStore) WriteSnapshot(state []models.Todo, atSeq uint64) error {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	payload, err := json.Marshal(snapshotFile{Seq: atSeq, Todos: state})
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(es.snapshotPath, payload, 0o644); err != nil {
+		return err
+	}
+
+	if err := es.file.Close(); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(es.logPath, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	es.file = file
+	es.sinceSnap = 0
+	return nil
+}
+
+type snapshotFile struct {
+	Seq   uint64        `json:"seq"`
+	Todos []models.Todo `json:"todos"`
+}
+
+func (es *EventStore) readSnapshot() (snapshotFile, bool, error) {
+	data, err := os.ReadFile(es.snapshotPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return snapshotFile{}, false, nil
+		}
+		return snapshotFile{}, false, err
+	}
+
+	var snap snapshotFile
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return snapshotFile{}, false, err
+	}
+	return snap, true, nil
+}
+
+// Close releases the underlying log file handle.
+func (es *EventStore) Close() error {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	return es.file.Close()
+}
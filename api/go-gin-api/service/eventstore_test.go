@@ -0,0 +1,66 @@
+package service
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"spicytodo-go-api/events"
+	"spicytodo-go-api/models"
+	"testing"
+)
+
+func TestEventStoreAppendAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.log")
+
+	store, err := NewEventStore(path)
+	if err != nil {
+		t.Fatalf("NewEventStore() error = %v", err)
+	}
+
+	todo := models.Todo{ID: "abc", Text: "Test"}
+	if _, _, err := store.Append(events.TypeTodoCreated, events.TodoCreated{Todo: todo}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	replayed := make(map[string]*models.Todo)
+	apply := func(record events.Record) error {
+		var e events.TodoCreated
+		if record.Type != events.TypeTodoCreated {
+			return nil
+		}
+		if err := json.Unmarshal(record.Data, &e); err != nil {
+			return err
+		}
+		replayed[e.Todo.ID] = &e.Todo
+		return nil
+	}
+
+	n, err := store.Replay(apply)
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Replay() applied %d records, want 1", n)
+	}
+	if _, ok := replayed["abc"]; !ok {
+		t.Error("expected replayed todo 'abc' to be present")
+	}
+}
+
+func TestEventStoreSince(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.log")
+	store, _ := NewEventStore(path)
+
+	store.Append(events.TypeTodoCreated, events.TodoCreated{Todo: models.Todo{ID: "1"}})
+	store.Append(events.TypeTodoCreated, events.TodoCreated{Todo: models.Todo{ID: "2"}})
+
+	records, err := store.Since(1)
+	if err != nil {
+		t.Fatalf("Since() error = %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("Since(1) returned %d records, want 1", len(records))
+	}
+	if records[0].Seq != 2 {
+		t.Errorf("Since(1)[0].Seq = %d, want 2", records[0].Seq)
+	}
+}
@@ -0,0 +1,391 @@
+package service
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"spicytodo-go-api/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var csvHeader = []string{
+	"ID", "Text", "Priority", "Completed", "DueDate", "StartDate", "Reminders",
+	"Tags", "Category", "CreatedAt", "UpdatedAt",
+}
+
+// EncodeCSVTo streams todos as RFC 4180 CSV (a header row followed by one
+// row per todo) directly to w, so an export never has to hold the whole
+// encoded document in memory before writing it out. Tags are joined with
+// ";" into a single column.
+func EncodeCSVTo(w io.Writer, todos []models.Todo) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, todo := range todos {
+		if err := cw.Write(todoToCSVRow(todo)); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// EncodeNDJSONTo streams todos as newline-delimited JSON, one todo per
+// line, so pipe-friendly tools (jq, grep) can process an export without
+// waiting for the whole array to download.
+func EncodeNDJSONTo(w io.Writer, todos []models.Todo) error {
+	enc := json.NewEncoder(w)
+	for _, todo := range todos {
+		if err := enc.Encode(todo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func todoToCSVRow(todo models.Todo) []string {
+	return []string{
+		todo.ID,
+		todo.Text,
+		string(todo.Priority),
+		strconv.FormatBool(todo.Completed),
+		strPtrValue(todo.DueDate),
+		strPtrValue(todo.StartDate),
+		remindersToCSV(todo.Reminders),
+		strings.Join(todo.Tags, ";"),
+		strPtrValue(todo.Category),
+		todo.CreatedAt.Format(time.RFC3339),
+		todo.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func strPtrValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// reminderToken renders one Reminder as the compact form remindersToCSV
+// joins with ";": "at:<RFC3339>" for an absolute reminder, or
+// "<relativeTo>:<offsetSeconds>" (e.g. "dueDate:-3600") for a relative one.
+func reminderToken(r models.Reminder) string {
+	if r.At != nil {
+		return "at:" + r.At.UTC().Format(time.RFC3339)
+	}
+	return fmt.Sprintf("%s:%d", r.RelativeTo, r.OffsetSeconds)
+}
+
+// remindersToCSV joins todo's reminders into a single ";"-separated column,
+// the same way Tags is joined.
+func remindersToCSV(reminders []models.Reminder) string {
+	tokens := make([]string, len(reminders))
+	for i, r := range reminders {
+		tokens[i] = reminderToken(r)
+	}
+	return strings.Join(tokens, ";")
+}
+
+// parseReminderToken parses one token produced by reminderToken, reporting
+// ok=false for anything malformed.
+func parseReminderToken(token string) (models.Reminder, bool) {
+	kind, rest, ok := strings.Cut(token, ":")
+	if !ok {
+		return models.Reminder{}, false
+	}
+
+	if kind == "at" {
+		t, err := time.Parse(time.RFC3339, rest)
+		if err != nil {
+			return models.Reminder{}, false
+		}
+		return models.Reminder{At: &t}, true
+	}
+
+	offset, err := strconv.Atoi(rest)
+	if err != nil {
+		return models.Reminder{}, false
+	}
+	return models.Reminder{RelativeTo: models.ReminderAnchor(kind), OffsetSeconds: offset}, true
+}
+
+// parseRemindersCSV parses a ";"-separated Reminders column produced by
+// remindersToCSV, skipping any token that fails to parse.
+func parseRemindersCSV(value string) []models.Reminder {
+	if value == "" {
+		return nil
+	}
+	var reminders []models.Reminder
+	for _, token := range strings.Split(value, ";") {
+		if r, ok := parseReminderToken(token); ok {
+			reminders = append(reminders, r)
+		}
+	}
+	return reminders
+}
+
+// CSVColumnMapping maps a source CSV header name (case-insensitive) to the
+// canonical TodoCreate field it should populate: "text", "priority",
+// "completed", "duedate", "startdate", "reminders", "tags", or "category".
+// It lets a caller import CSV files that use their own header names (e.g. a
+// "due" column instead of "DueDate") without first rewriting the file.
+type CSVColumnMapping map[string]string
+
+// DefaultCSVColumnMapping is used when DecodeCSV is given a nil mapping; it
+// matches EncodeCSV's own header names, so round-tripping an export needs
+// no mapping at all.
+var DefaultCSVColumnMapping = CSVColumnMapping{
+	"text":      "text",
+	"priority":  "priority",
+	"completed": "completed",
+	"duedate":   "duedate",
+	"startdate": "startdate",
+	"reminders": "reminders",
+	"tags":      "tags",
+	"category":  "category",
+}
+
+// DecodeCSV parses CSV produced by EncodeCSV (or any CSV carrying a header
+// row) into TodoCreate values. mapping resolves each header cell to a
+// canonical field; pass nil to use DefaultCSVColumnMapping. tagSep splits
+// the tags column into individual tags; pass "" to default to ";".
+// Rows that fail to parse are skipped and reported as "Row N: ..." errors,
+// matching ImportTodos' convention.
+func DecodeCSV(r io.Reader, mapping CSVColumnMapping, tagSep string) ([]models.TodoCreate, []string) {
+	if mapping == nil {
+		mapping = DefaultCSVColumnMapping
+	}
+	if tagSep == "" {
+		tagSep = ";"
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	rows, err := reader.ReadAll()
+	if err != nil || len(rows) == 0 {
+		return nil, []string{"Row 1: invalid CSV input"}
+	}
+
+	header := rows[0]
+	cols := make(map[string]int, len(header))
+	for i, name := range header {
+		if field, ok := mapping[strings.ToLower(strings.TrimSpace(name))]; ok {
+			cols[field] = i
+		}
+	}
+
+	var todos []models.TodoCreate
+	var errs []string
+
+	for i, row := range rows[1:] {
+		rowNum := i + 2 // account for the header row and 1-based counting
+		text := cellAt(row, cols, "text")
+		if text == "" {
+			errs = append(errs, fmt.Sprintf("Row %d: Text is required", rowNum))
+			continue
+		}
+
+		todo := models.TodoCreate{
+			Text:     text,
+			Priority: models.Priority(cellAt(row, cols, "priority")),
+		}
+		if completed, err := strconv.ParseBool(cellAt(row, cols, "completed")); err == nil {
+			todo.Completed = completed
+		}
+		if due := cellAt(row, cols, "duedate"); due != "" {
+			todo.DueDate = &due
+		}
+		if start := cellAt(row, cols, "startdate"); start != "" {
+			todo.StartDate = &start
+		}
+		if reminders := cellAt(row, cols, "reminders"); reminders != "" {
+			todo.Reminders = parseRemindersCSV(reminders)
+		}
+		if tags := cellAt(row, cols, "tags"); tags != "" {
+			todo.Tags = strings.Split(tags, tagSep)
+		}
+		if category := cellAt(row, cols, "category"); category != "" {
+			todo.Category = &category
+		}
+
+		todos = append(todos, todo)
+	}
+
+	return todos, errs
+}
+
+func cellAt(row []string, cols map[string]int, name string) string {
+	idx, ok := cols[name]
+	if !ok || idx >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[idx])
+}
+
+// DecodeNDJSON parses newline-delimited JSON (one todo object per line)
+// into TodoCreate values. Blank lines are skipped; a line that fails to
+// unmarshal is reported as a "Row N: ..." error, matching DecodeCSV's
+// convention, and does not abort the remaining lines.
+func DecodeNDJSON(r io.Reader) ([]models.TodoCreate, []string) {
+	var todos []models.TodoCreate
+	var errs []string
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var todo models.TodoCreate
+		if err := json.Unmarshal([]byte(line), &todo); err != nil {
+			errs = append(errs, fmt.Sprintf("Row %d: %v", lineNum, err))
+			continue
+		}
+		if todo.Text == "" {
+			errs = append(errs, fmt.Sprintf("Row %d: Text is required", lineNum))
+			continue
+		}
+
+		todos = append(todos, todo)
+	}
+
+	return todos, errs
+}
+
+var priorityEmoji = map[models.Priority]string{
+	models.PriorityHigh:   "🔴",
+	models.PriorityMedium: "🟡",
+	models.PriorityLow:    "🟢",
+}
+
+// EncodeMarkdown renders todos as a GitHub-flavored task list grouped by
+// category, e.g.:
+//
+//	## Work
+//	- [ ] 🔴 Ship the release (2026-01-02)
+//	- [x] 🟡 Write release notes
+func EncodeMarkdown(todos []models.Todo) string {
+	byCategory := make(map[string][]models.Todo)
+	for _, todo := range todos {
+		category := "Uncategorized"
+		if todo.Category != nil && *todo.Category != "" {
+			category = *todo.Category
+		}
+		byCategory[category] = append(byCategory[category], todo)
+	}
+
+	categories := make([]string, 0, len(byCategory))
+	for category := range byCategory {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	var sb strings.Builder
+	for _, category := range categories {
+		sb.WriteString("## " + category + "\n")
+		for _, todo := range byCategory[category] {
+			sb.WriteString(markdownTaskLine(todo) + "\n")
+		}
+		sb.WriteString("\n")
+	}
+	return strings.TrimRight(sb.String(), "\n") + "\n"
+}
+
+func markdownTaskLine(todo models.Todo) string {
+	box := "[ ]"
+	if todo.Completed {
+		box = "[x]"
+	}
+
+	emoji := priorityEmoji[todo.Priority]
+	line := "- " + box
+	if emoji != "" {
+		line += " " + emoji
+	}
+	line += " " + todo.Text
+	if todo.DueDate != nil && *todo.DueDate != "" {
+		line += " (" + *todo.DueDate + ")"
+	}
+	return line
+}
+
+var (
+	markdownHeadingRe = regexp.MustCompile(`^##\s+(.+)$`)
+	markdownTaskRe    = regexp.MustCompile(`^-\s+\[( |x|X)\]\s*(?:(🔴|🟡|🟢)\s*)?(.+?)(?:\s+\(([^)]+)\))?$`)
+)
+
+// DecodeMarkdown parses the task-list shape produced by EncodeMarkdown back
+// into TodoCreate values, recovering Category from the section heading and
+// Priority/DueDate from the emoji and trailing parenthetical.
+func DecodeMarkdown(r io.Reader) ([]models.TodoCreate, []string) {
+	var todos []models.TodoCreate
+	var errs []string
+
+	scanner := bufio.NewScanner(r)
+	currentCategory := ""
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if m := markdownHeadingRe.FindStringSubmatch(line); m != nil {
+			currentCategory = m[1]
+			continue
+		}
+
+		m := markdownTaskRe.FindStringSubmatch(line)
+		if m == nil {
+			errs = append(errs, fmt.Sprintf("Row %d: unrecognized task line", lineNum))
+			continue
+		}
+
+		todo := models.TodoCreate{
+			Text:      strings.TrimSpace(m[3]),
+			Completed: strings.EqualFold(m[1], "x"),
+			Priority:  priorityFromEmoji(m[2]),
+		}
+		if todo.Text == "" {
+			errs = append(errs, fmt.Sprintf("Row %d: Text is required", lineNum))
+			continue
+		}
+		if currentCategory != "" && currentCategory != "Uncategorized" {
+			category := currentCategory
+			todo.Category = &category
+		}
+		if due := m[4]; due != "" {
+			dueDate := due
+			todo.DueDate = &dueDate
+		}
+
+		todos = append(todos, todo)
+	}
+
+	return todos, errs
+}
+
+func priorityFromEmoji(emoji string) models.Priority {
+	for priority, e := range priorityEmoji {
+		if e == emoji {
+			return priority
+		}
+	}
+	return models.PriorityMedium
+}
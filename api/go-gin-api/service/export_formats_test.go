@@ -0,0 +1,67 @@
+package service
+
+import (
+	"spicytodo-go-api/models"
+	"strings"
+	"testing"
+)
+
+func TestEncodeDecodeNDJSONRoundTrip(t *testing.T) {
+	todos := []models.Todo{
+		{ID: "1", Text: "First"},
+		{ID: "2", Text: "Second"},
+	}
+
+	var sb strings.Builder
+	if err := EncodeNDJSONTo(&sb, todos); err != nil {
+		t.Fatalf("EncodeNDJSONTo: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(sb.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+}
+
+func TestDecodeNDJSONSkipsInvalidLines(t *testing.T) {
+	input := `{"text":"Valid"}` + "\n" + `not json` + "\n" + `{"text":""}` + "\n"
+
+	todos, errs := DecodeNDJSON(strings.NewReader(input))
+	if len(todos) != 1 {
+		t.Fatalf("expected 1 valid todo, got %d", len(todos))
+	}
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %d: %v", len(errs), errs)
+	}
+}
+
+func TestDecodeCSVWithCustomMapping(t *testing.T) {
+	csv := "note,labels\nCustom header row,work|urgent\n"
+	mapping := CSVColumnMapping{"note": "text", "labels": "tags"}
+
+	todos, errs := DecodeCSV(strings.NewReader(csv), mapping, "|")
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if len(todos) != 1 {
+		t.Fatalf("expected 1 todo, got %d", len(todos))
+	}
+	if todos[0].Text != "Custom header row" {
+		t.Errorf("got Text=%q", todos[0].Text)
+	}
+	if len(todos[0].Tags) != 2 || todos[0].Tags[0] != "work" || todos[0].Tags[1] != "urgent" {
+		t.Errorf("got Tags=%v", todos[0].Tags)
+	}
+}
+
+func TestDecodeCSVDefaultMapping(t *testing.T) {
+	csv := "Text,Tags\nDefault header row,a;b\n"
+
+	todos, errs := DecodeCSV(strings.NewReader(csv), nil, "")
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if len(todos) != 1 || todos[0].Text != "Default header row" {
+		t.Fatalf("got todos=%v", todos)
+	}
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"encoding/json"
+	"spicytodo-go-api/events"
+	"spicytodo-go-api/models"
+)
+
+// History returns every recorded event that touched the todo with the given
+// ID, oldest first, for `GET /api/todos/:id/history`.
+func (s *TodoService) History(id string) ([]events.Record, error) {
+	if s.eventStore == nil {
+		return nil, nil
+	}
+
+	records, err := s.eventStore.All()
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]events.Record, 0)
+	for _, record := range records {
+		if recordTodoID(record) == id {
+			history = append(history, record)
+		}
+	}
+	return history, nil
+}
+
+// Rebuild discards the in-memory projection and rebuilds it from scratch by
+// replaying the event log, for `POST /api/admin/replay`. It reports how many
+// records were applied.
+func (s *TodoService) Rebuild() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.eventStore == nil {
+		return 0, nil
+	}
+
+	return s.eventStore.Replay(s.applyEvent)
+}
+
+// Undo reverts a todo to the state captured by the most recent full
+// snapshot in its history (the Todo carried by a TodoCreated or TodoUpdated
+// event) before its current, last-recorded event. A single Undo call steps
+// back to that snapshot as a whole, so an intervening toggle or snooze is
+// undone along with whatever else changed since. It reports false if there
+// is no prior state to restore (e.g. the todo has no history, or its only
+// event is the create).
+func (s *TodoService) Undo(id string) (*models.Todo, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	history, err := s.History(id)
+	if err != nil || len(history) < 2 {
+		return nil, false
+	}
+
+	for i := len(history) - 2; i >= 0; i-- {
+		todo, ok := todoFromRecord(history[i])
+		if !ok {
+			continue
+		}
+
+		restored := todo
+		s.store.Put(&restored)
+		s.notify(events.TypeTodoUpdated, events.TodoUpdated{Todo: restored}, restored.ID, &restored)
+		return &restored, true
+	}
+
+	return nil, false
+}
+
+// recordTodoID extracts the todo ID a record's payload refers to, or "" for
+// record types that don't carry one (e.g. a Snapshot).
+func recordTodoID(record events.Record) string {
+	switch record.Type {
+	case events.TypeTodoCreated:
+		var e events.TodoCreated
+		if err := json.Unmarshal(record.Data, &e); err != nil {
+			return ""
+		}
+		return e.Todo.ID
+	case events.TypeTodoUpdated:
+		var e events.TodoUpdated
+		if err := json.Unmarshal(record.Data, &e); err != nil {
+			return ""
+		}
+		return e.Todo.ID
+	case events.TypeTodoDeleted:
+		var e events.TodoDeleted
+		if err := json.Unmarshal(record.Data, &e); err != nil {
+			return ""
+		}
+		return e.ID
+	case events.TypeTodoCompleted:
+		var e events.TodoCompleted
+		if err := json.Unmarshal(record.Data, &e); err != nil {
+			return ""
+		}
+		return e.ID
+	case events.TypeTodoSnoozed:
+		var e events.TodoSnoozed
+		if err := json.Unmarshal(record.Data, &e); err != nil {
+			return ""
+		}
+		return e.ID
+	default:
+		return ""
+	}
+}
+
+// todoFromRecord extracts the full Todo state carried by a TodoCreated or
+// TodoUpdated record. Other record types don't carry a full snapshot and
+// return ok=false.
+func todoFromRecord(record events.Record) (models.Todo, bool) {
+	switch record.Type {
+	case events.TypeTodoCreated:
+		var e events.TodoCreated
+		if err := json.Unmarshal(record.Data, &e); err != nil {
+			return models.Todo{}, false
+		}
+		return e.Todo, true
+	case events.TypeTodoUpdated:
+		var e events.TodoUpdated
+		if err := json.Unmarshal(record.Data, &e); err != nil {
+			return models.Todo{}, false
+		}
+		return e.Todo, true
+	default:
+		return models.Todo{}, false
+	}
+}
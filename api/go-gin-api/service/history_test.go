@@ -0,0 +1,50 @@
+package service
+
+import (
+	"spicytodo-go-api/models"
+	"testing"
+)
+
+func TestHistoryReturnsEventsForTodo(t *testing.T) {
+	s := newTestServiceWithEvents(t)
+
+	todo := s.Create(models.TodoCreate{Text: "Track me"})
+	s.Toggle(todo.ID)
+
+	history, err := s.History(todo.ID)
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("History() returned %d records, want 2", len(history))
+	}
+}
+
+func TestUndoRestoresPriorSnapshot(t *testing.T) {
+	s := newTestServiceWithEvents(t)
+
+	todo := s.Create(models.TodoCreate{Text: "Original"})
+	s.Update(todo.ID, models.TodoUpdate{Text: strUpdatePtr("Changed")})
+
+	restored, ok := s.Undo(todo.ID)
+	if !ok {
+		t.Fatal("Undo() returned ok = false, want true")
+	}
+	if restored.Text != "Original" {
+		t.Errorf("Undo() restored Text = %q, want %q", restored.Text, "Original")
+	}
+}
+
+func TestUndoWithNoPriorStateFails(t *testing.T) {
+	s := newTestServiceWithEvents(t)
+
+	todo := s.Create(models.TodoCreate{Text: "Fresh"})
+
+	if _, ok := s.Undo(todo.ID); ok {
+		t.Error("Undo() on a just-created todo should have nothing to revert to")
+	}
+}
+
+func strUpdatePtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,370 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"spicytodo-go-api/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// icsLineWidth is RFC 5545's recommended maximum content line length (in
+// octets, CRLF excluded) before folding.
+const icsLineWidth = 75
+
+// icsDateTimeFormat renders a DATE-TIME value in the UTC "floating as Z"
+// form RFC 5545 section 3.3.5 allows, which keeps encode/decode simple
+// since the rest of this service doesn't track per-todo time zones either.
+const icsDateTimeFormat = "20060102T150405Z"
+
+// EncodeICSTo streams todos as an iCalendar (RFC 5545) VCALENDAR document,
+// one VTODO per todo, directly to w. Lines are folded to icsLineWidth
+// octets per section 3.1, continued with CRLF followed by a single space.
+func EncodeICSTo(w io.Writer, todos []models.Todo) error {
+	if err := writeICSLines(w, []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//spicytodo-go-api//EN",
+	}); err != nil {
+		return err
+	}
+
+	for _, todo := range todos {
+		if err := writeICSLines(w, vTodoLines(todo)); err != nil {
+			return err
+		}
+	}
+
+	return writeICSLines(w, []string{"END:VCALENDAR"})
+}
+
+// vTodoLines renders one todo's VTODO component as unfolded content lines.
+func vTodoLines(todo models.Todo) []string {
+	lines := []string{
+		"BEGIN:VTODO",
+		"UID:" + todo.ID,
+		"SUMMARY:" + icsEscape(todo.Text),
+		"CREATED:" + todo.CreatedAt.UTC().Format(icsDateTimeFormat),
+		"LAST-MODIFIED:" + todo.UpdatedAt.UTC().Format(icsDateTimeFormat),
+		"STATUS:" + icsStatus(todo.Completed),
+		"PRIORITY:" + strconv.Itoa(icsPriority(todo.Priority)),
+	}
+
+	if due, ok := icsDueLine(todo); ok {
+		lines = append(lines, due)
+	}
+
+	if categories := icsCategories(todo); categories != "" {
+		lines = append(lines, "CATEGORIES:"+categories)
+	}
+
+	if rrule, ok := icsRRule(todo); ok {
+		lines = append(lines, "RRULE:"+rrule)
+	}
+
+	lines = append(lines, icsValarmLines(todo)...)
+
+	return append(lines, "END:VTODO")
+}
+
+// icsValarmLines renders one VALARM subcomponent per fire time
+// reminderFireTimes computes for todo, so a calendar client's own reminders
+// match the ones the API surfaces via GetUpcomingReminders.
+func icsValarmLines(todo models.Todo) []string {
+	var lines []string
+	for _, fireTime := range reminderFireTimes(&todo) {
+		lines = append(lines,
+			"BEGIN:VALARM",
+			"ACTION:DISPLAY",
+			"DESCRIPTION:"+icsEscape(todo.Text),
+			"TRIGGER;VALUE=DATE-TIME:"+fireTime.UTC().Format(icsDateTimeFormat),
+			"END:VALARM",
+		)
+	}
+	return lines
+}
+
+func icsStatus(completed bool) string {
+	if completed {
+		return "COMPLETED"
+	}
+	return "NEEDS-ACTION"
+}
+
+// icsPriority maps Priority onto RFC 5545's PRIORITY scale (1 highest, 9
+// lowest, 0/absent undefined).
+func icsPriority(priority models.Priority) int {
+	switch priority {
+	case models.PriorityHigh:
+		return 1
+	case models.PriorityLow:
+		return 9
+	default:
+		return 5
+	}
+}
+
+func priorityFromICS(value string) models.Priority {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return models.PriorityMedium
+	}
+	switch {
+	case n >= 1 && n <= 3:
+		return models.PriorityHigh
+	case n >= 7 && n <= 9:
+		return models.PriorityLow
+	default:
+		return models.PriorityMedium
+	}
+}
+
+// icsDueLine renders todo's DUE property as a date-only value (VALUE=DATE).
+// Reminders are encoded separately as VALARMs (see icsValarmLines) rather
+// than folded into DUE's own precision, since a todo can carry several of
+// them, each possibly anchored off StartDate instead.
+func icsDueLine(todo models.Todo) (string, bool) {
+	if todo.DueDate == nil {
+		return "", false
+	}
+
+	dueDate, err := time.Parse("2006-01-02", *todo.DueDate)
+	if err != nil {
+		return "", false
+	}
+	return "DUE;VALUE=DATE:" + dueDate.Format("20060102"), true
+}
+
+// icsCategories joins Tags and Category into one RFC 5545 CATEGORIES value
+// (comma-separated, each token escaped).
+func icsCategories(todo models.Todo) string {
+	tokens := make([]string, 0, len(todo.Tags)+1)
+	for _, tag := range todo.Tags {
+		tokens = append(tokens, icsEscape(tag))
+	}
+	if todo.Category != nil && *todo.Category != "" {
+		tokens = append(tokens, icsEscape(*todo.Category))
+	}
+	return strings.Join(tokens, ",")
+}
+
+// icsRRule derives an RRULE value using the same precedence
+// createNextOccurrence does: todo.Recurrence verbatim if set, otherwise the
+// legacy RecurrenceRule enum's RRULE shorthand.
+func icsRRule(todo models.Todo) (string, bool) {
+	return effectiveRRule(&todo)
+}
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, "\n", `\n`)
+
+func icsEscape(s string) string {
+	return icsEscaper.Replace(s)
+}
+
+// icsUnescape reverses icsEscape's backslash escaping of ";", ",", "\", and
+// newlines.
+func icsUnescape(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			switch s[i+1] {
+			case 'n', 'N':
+				sb.WriteByte('\n')
+			default:
+				sb.WriteByte(s[i+1])
+			}
+			i++
+			continue
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
+
+// writeICSLines folds and CRLF-terminates each of lines in turn.
+func writeICSLines(w io.Writer, lines []string) error {
+	for _, line := range lines {
+		if err := writeICSLine(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeICSLine folds line to icsLineWidth octets per RFC 5545 section 3.1:
+// every continuation is a CRLF followed by a single leading space, which
+// itself counts against the following physical line's budget.
+func writeICSLine(w io.Writer, line string) error {
+	budget := icsLineWidth
+	for len(line) > budget {
+		chunk := line[:budget]
+		line = line[budget:]
+		if _, err := io.WriteString(w, chunk+"\r\n "); err != nil {
+			return err
+		}
+		budget = icsLineWidth - 1
+	}
+	_, err := io.WriteString(w, line+"\r\n")
+	return err
+}
+
+// icsProperty is one parsed content line: its parameters (e.g. VALUE=DATE)
+// and its (still-escaped) value.
+type icsProperty struct {
+	params map[string]string
+	value  string
+}
+
+// DecodeICS parses an iCalendar VCALENDAR stream produced by EncodeICSTo
+// (or any RFC 5545 document whose VTODOs use the properties it writes)
+// into TodoCreate values. A VTODO missing SUMMARY is reported as a
+// "Row N: ..." error, matching the other Decode* functions' convention,
+// where N counts VTODOs rather than lines.
+func DecodeICS(r io.Reader) ([]models.TodoCreate, []string) {
+	var todos []models.TodoCreate
+	var errs []string
+
+	var current map[string]icsProperty
+	inAlarm := false
+	vTodoNum := 0
+
+	for _, line := range unfoldICSLines(r) {
+		switch {
+		case line == "BEGIN:VTODO":
+			current = make(map[string]icsProperty)
+
+		case line == "END:VTODO":
+			if current == nil {
+				continue
+			}
+			vTodoNum++
+			todo, err := todoFromICSProperties(current)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("Row %d: %v", vTodoNum, err))
+			} else {
+				todos = append(todos, todo)
+			}
+			current = nil
+
+		case line == "BEGIN:VALARM":
+			inAlarm = true
+
+		case line == "END:VALARM":
+			inAlarm = false
+
+		case current != nil && !inAlarm && line != "":
+			name, params, value := parseICSLine(line)
+			current[name] = icsProperty{params: params, value: value}
+		}
+	}
+
+	return todos, errs
+}
+
+// unfoldICSLines reads r's content lines, undoing RFC 5545 line folding:
+// a line starting with a space or tab is a continuation of the previous one.
+func unfoldICSLines(r io.Reader) []string {
+	scanner := bufio.NewScanner(r)
+	var lines []string
+	for scanner.Scan() {
+		raw := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(raw, " ") || strings.HasPrefix(raw, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += raw[1:]
+			continue
+		}
+		lines = append(lines, raw)
+	}
+	return lines
+}
+
+// parseICSLine splits one unfolded content line into its property name,
+// parameters, and value, e.g. "DUE;VALUE=DATE:20260101" -> ("DUE",
+// {"VALUE":"DATE"}, "20260101").
+func parseICSLine(line string) (name string, params map[string]string, value string) {
+	idx := strings.Index(line, ":")
+	if idx < 0 {
+		return strings.ToUpper(line), nil, ""
+	}
+
+	head := line[:idx]
+	value = line[idx+1:]
+
+	parts := strings.Split(head, ";")
+	name = strings.ToUpper(parts[0])
+	if len(parts) > 1 {
+		params = make(map[string]string, len(parts)-1)
+		for _, p := range parts[1:] {
+			if k, v, ok := strings.Cut(p, "="); ok {
+				params[strings.ToUpper(k)] = v
+			}
+		}
+	}
+	return name, params, value
+}
+
+func todoFromICSProperties(props map[string]icsProperty) (models.TodoCreate, error) {
+	summary, ok := props["SUMMARY"]
+	if !ok || summary.value == "" {
+		return models.TodoCreate{}, fmt.Errorf("missing SUMMARY")
+	}
+
+	todo := models.TodoCreate{
+		Text:      icsUnescape(summary.value),
+		Priority:  models.PriorityMedium,
+		Completed: strings.EqualFold(props["STATUS"].value, "COMPLETED"),
+	}
+
+	if p, ok := props["PRIORITY"]; ok {
+		todo.Priority = priorityFromICS(p.value)
+	}
+
+	if due, ok := props["DUE"]; ok {
+		if dueDate, reminderAt, ok := splitICSDateTime(due); ok {
+			todo.DueDate = &dueDate
+			if reminderAt != nil {
+				todo.Reminders = []models.Reminder{{At: reminderAt}}
+			}
+		}
+	}
+
+	if categories, ok := props["CATEGORIES"]; ok && categories.value != "" {
+		for _, tok := range strings.Split(categories.value, ",") {
+			if tag := icsUnescape(strings.TrimSpace(tok)); tag != "" {
+				todo.Tags = append(todo.Tags, tag)
+			}
+		}
+	}
+
+	if rrule, ok := props["RRULE"]; ok && rrule.value != "" {
+		todo.Recurrence = rrule.value
+	}
+
+	return todo, nil
+}
+
+// splitICSDateTime parses a DUE property's value into a "2006-01-02"
+// DueDate and, if it carried a time of day, the absolute instant as
+// reminderAt (imported as a single absolute Reminder, preserving a pulled
+// external event's alarm time without conflating it with DUE's own
+// date-only precision).
+func splitICSDateTime(p icsProperty) (dueDate string, reminderAt *time.Time, ok bool) {
+	value := p.value
+	if p.params["VALUE"] == "DATE" || len(value) == 8 {
+		t, err := time.Parse("20060102", value)
+		if err != nil {
+			return "", nil, false
+		}
+		return t.Format("2006-01-02"), nil, true
+	}
+
+	t, err := time.Parse("20060102T150405Z", value)
+	if err != nil {
+		t, err = time.Parse("20060102T150405", value)
+		if err != nil {
+			return "", nil, false
+		}
+	}
+	return t.Format("2006-01-02"), &t, true
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"spicytodo-go-api/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncodeDecodeICSRoundTrip(t *testing.T) {
+	dueDate := "2026-08-01"
+	reminderAt := time.Date(2026, 8, 1, 9, 30, 0, 0, time.UTC)
+	category := "Work"
+
+	todos := []models.Todo{
+		{
+			ID:        "1",
+			Text:      "Ship the release",
+			Priority:  models.PriorityHigh,
+			Completed: true,
+			Tags:      []string{"backend", "urgent"},
+			Category:  &category,
+			DueDate:   &dueDate,
+			Reminders: []models.Reminder{{At: &reminderAt}},
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+	}
+
+	var sb strings.Builder
+	if err := EncodeICSTo(&sb, todos); err != nil {
+		t.Fatalf("EncodeICSTo: %v", err)
+	}
+
+	ics := sb.String()
+	if !strings.Contains(ics, "BEGIN:VCALENDAR") || !strings.Contains(ics, "BEGIN:VTODO") {
+		t.Fatalf("expected a VCALENDAR/VTODO document, got:\n%s", ics)
+	}
+
+	decoded, errs := DecodeICS(strings.NewReader(ics))
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if len(decoded) != 1 {
+		t.Fatalf("expected 1 todo, got %d", len(decoded))
+	}
+
+	got := decoded[0]
+	if got.Text != "Ship the release" {
+		t.Errorf("Text = %q", got.Text)
+	}
+	if !got.Completed {
+		t.Error("expected Completed to survive the round trip")
+	}
+	if got.Priority != models.PriorityHigh {
+		t.Errorf("Priority = %q", got.Priority)
+	}
+	if got.DueDate == nil || *got.DueDate != dueDate {
+		t.Errorf("DueDate = %v, want %v", got.DueDate, dueDate)
+	}
+	// DUE is always date-only now (reminders are encoded separately as
+	// VALARMs, see TestEncodeICSValarmForReminder), so it carries no
+	// reminder information to decode back.
+	if !strings.Contains(ics, "BEGIN:VALARM") {
+		t.Errorf("expected the reminder to be encoded as a VALARM, got:\n%s", ics)
+	}
+
+	wantTags := map[string]bool{"backend": true, "urgent": true, "work": true}
+	if len(got.Tags) != len(wantTags) {
+		t.Fatalf("Tags = %v", got.Tags)
+	}
+	for _, tag := range got.Tags {
+		if !wantTags[strings.ToLower(tag)] {
+			t.Errorf("unexpected tag %q", tag)
+		}
+	}
+}
+
+func TestEncodeICSFoldsLongLines(t *testing.T) {
+	todos := []models.Todo{
+		{ID: "1", Text: strings.Repeat("a", 200)},
+	}
+
+	var sb strings.Builder
+	if err := EncodeICSTo(&sb, todos); err != nil {
+		t.Fatalf("EncodeICSTo: %v", err)
+	}
+
+	for _, line := range strings.Split(sb.String(), "\r\n") {
+		if len(line) > icsLineWidth {
+			t.Fatalf("unfolded line exceeds %d octets: %q", icsLineWidth, line)
+		}
+	}
+}
+
+func TestEncodeICSRecurrence(t *testing.T) {
+	todos := []models.Todo{
+		{ID: "1", Text: "Weekly sync", RecurrenceRule: models.RecurrenceWeekly},
+	}
+
+	var sb strings.Builder
+	if err := EncodeICSTo(&sb, todos); err != nil {
+		t.Fatalf("EncodeICSTo: %v", err)
+	}
+
+	if !strings.Contains(sb.String(), "RRULE:FREQ=WEEKLY") {
+		t.Errorf("expected RRULE:FREQ=WEEKLY, got:\n%s", sb.String())
+	}
+}
+
+func TestEncodeICSValarmForReminder(t *testing.T) {
+	dueDate := "2026-08-01"
+	reminderAt := time.Date(2026, 8, 1, 9, 30, 0, 0, time.UTC)
+	todos := []models.Todo{
+		{ID: "1", Text: "Standup", DueDate: &dueDate, Reminders: []models.Reminder{{At: &reminderAt}}},
+	}
+
+	var sb strings.Builder
+	if err := EncodeICSTo(&sb, todos); err != nil {
+		t.Fatalf("EncodeICSTo: %v", err)
+	}
+
+	ics := sb.String()
+	if !strings.Contains(ics, "BEGIN:VALARM") || !strings.Contains(ics, "TRIGGER;VALUE=DATE-TIME:20260801T093000Z") {
+		t.Fatalf("expected a VALARM triggering at the reminder time, got:\n%s", ics)
+	}
+
+	// A VALARM's own properties (e.g. ACTION, DESCRIPTION) must not leak
+	// into the VTODO's decoded fields.
+	decoded, errs := DecodeICS(strings.NewReader(ics))
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if len(decoded) != 1 || decoded[0].Text != "Standup" {
+		t.Fatalf("expected the VALARM to be ignored by DecodeICS, got %v", decoded)
+	}
+}
+
+func TestDecodeICSSkipsVTodosMissingSummary(t *testing.T) {
+	input := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"UID:1\r\n" +
+		"END:VTODO\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"SUMMARY:Valid\r\n" +
+		"END:VTODO\r\n" +
+		"END:VCALENDAR\r\n"
+
+	todos, errs := DecodeICS(strings.NewReader(input))
+	if len(todos) != 1 || todos[0].Text != "Valid" {
+		t.Fatalf("expected 1 valid todo, got %v", todos)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %d: %v", len(errs), errs)
+	}
+}
+
+func TestDecodeICSUnfoldsContinuationLines(t *testing.T) {
+	input := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"SUMMARY:Long task\r\n  that wraps\r\n" +
+		"END:VTODO\r\n" +
+		"END:VCALENDAR\r\n"
+
+	todos, errs := DecodeICS(strings.NewReader(input))
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if len(todos) != 1 || todos[0].Text != "Long task that wraps" {
+		t.Fatalf("expected unfolded summary, got %v", todos)
+	}
+}
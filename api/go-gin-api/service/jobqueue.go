@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"spicytodo-go-api/models"
+	"spicytodo-go-api/scheduler"
+	"time"
+)
+
+// newJobQueueFromEnv selects a scheduler.Queue backend the same way
+// newTodoStoreFromEnv picks a TodoStore: STORE=redis shares a queue across
+// API instances via REDIS_ADDR, anything else keeps it in-process.
+func newJobQueueFromEnv() scheduler.Queue {
+	switch os.Getenv("STORE") {
+	case "redis":
+		addr := os.Getenv("REDIS_ADDR")
+		if addr == "" {
+			addr = "localhost:6379"
+		}
+		return scheduler.NewRedisQueue(addr)
+	default:
+		return scheduler.NewMemoryQueue()
+	}
+}
+
+// SetJobQueue wires the job queue Create/Update/Toggle enqueue reminder and
+// recurrence jobs into. Optional: until it's called (e.g. unit tests that
+// construct a TodoService directly), NewTodoService's default in-memory
+// queue is used.
+func (s *TodoService) SetJobQueue(queue scheduler.Queue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobQueue = queue
+}
+
+// reminderJobID derives the job ID for todo's i'th reminder, so each one
+// can be enqueued/cancelled independently.
+func reminderJobID(todoID string, i int) string {
+	return scheduler.JobID(fmt.Sprintf("%s#%d", todoID, i), scheduler.JobReminderFire)
+}
+
+// enqueueReminderJob schedules a job per todo.Reminders entry, replacing any
+// jobs already queued for it, so a job persists across process restarts
+// even for backends (Redis) shared with other API instances. prevCount is
+// the reminder count before this update (0 for a newly created todo), so
+// trailing jobs left over from a shrunk reminder list get cancelled.
+func (s *TodoService) enqueueReminderJob(todo *models.Todo, prevCount int) {
+	times := reminderFireTimes(todo)
+	for i, fireAt := range times {
+		s.jobQueue.Enqueue(context.Background(), scheduler.Job{
+			ID:     reminderJobID(todo.ID, i),
+			Type:   scheduler.JobReminderFire,
+			TodoID: todo.ID,
+			FireAt: fireAt,
+			Status: models.JobStatusPending,
+		})
+	}
+	for i := len(times); i < prevCount; i++ {
+		s.jobQueue.Cancel(context.Background(), reminderJobID(todo.ID, i))
+	}
+}
+
+// enqueueRecurrenceJob schedules todo's next occurrence to be materialized,
+// fired immediately rather than waiting on ProcessRecurringTodos's periodic
+// sweep.
+func (s *TodoService) enqueueRecurrenceJob(todo *models.Todo) {
+	s.jobQueue.Enqueue(context.Background(), scheduler.Job{
+		ID:     scheduler.JobID(todo.ID, scheduler.JobRecurrenceCreateNext),
+		Type:   scheduler.JobRecurrenceCreateNext,
+		TodoID: todo.ID,
+		FireAt: time.Now(),
+		Status: models.JobStatusPending,
+	})
+}
+
+// ProcessJobQueue claims every job due by now and runs it: a reminder:fire
+// job dispatches its reminder, a recurrence:create-next job materializes
+// the next occurrence, and a caldav:push job pushes a todo to the
+// configured CalDAV server. It's meant to be polled the same way
+// ProcessRecurringTodos is, or driven by a worker loop in main. A job whose
+// handler errors is retried with backoff (see retryOrDeadLetter) rather
+// than dropped.
+func (s *TodoService) ProcessJobQueue() {
+	due, err := s.jobQueue.Due(context.Background(), time.Now(), 0)
+	if err != nil || len(due) == 0 {
+		return
+	}
+
+	for _, job := range due {
+		var handlerErr error
+		switch job.Type {
+		case scheduler.JobReminderFire:
+			s.dispatchReminder(job.TodoID)
+		case scheduler.JobRecurrenceCreateNext:
+			s.createNextOccurrenceByID(job.TodoID)
+		case scheduler.JobCalDAVPush:
+			handlerErr = s.dispatchCalDAVPush(job.TodoID)
+		}
+		if handlerErr != nil {
+			s.retryOrDeadLetter(job, handlerErr)
+		}
+	}
+}
+
+// retryOrDeadLetter re-enqueues job after Backoff(job.RetryCount) if it
+// hasn't exhausted its retries yet, otherwise files it in the dead letter
+// list for operator inspection/retry via the /api/worker endpoints.
+func (s *TodoService) retryOrDeadLetter(job scheduler.Job, handlerErr error) {
+	maxRetries := job.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = scheduler.DefaultMaxRetries
+	}
+
+	job.RetryCount++
+	job.LastError = handlerErr.Error()
+
+	if job.RetryCount >= maxRetries {
+		s.jobQueue.MoveToDeadLetter(context.Background(), job)
+		return
+	}
+
+	job.FireAt = time.Now().Add(scheduler.Backoff(job.RetryCount))
+	s.jobQueue.Enqueue(context.Background(), job)
+}
+
+// createNextOccurrenceByID looks up todo by ID and materializes its next
+// occurrence, for jobs that only carry the ID rather than the todo itself.
+func (s *TodoService) createNextOccurrenceByID(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	todo, exists := s.store.Get(id)
+	if !exists {
+		return
+	}
+	s.createNextOccurrence(todo)
+}
+
+// PendingJobs returns every job still queued, soonest-first, for
+// `GET /api/jobs`.
+func (s *TodoService) PendingJobs() ([]scheduler.Job, error) {
+	return s.jobQueue.List(context.Background())
+}
+
+// CancelJob removes a pending job by ID, for `DELETE /api/jobs/:id`.
+func (s *TodoService) CancelJob(id string) error {
+	return s.jobQueue.Cancel(context.Background(), id)
+}
+
+// QueueSummary reports how many jobs of each type are pending or
+// dead-lettered, for `GET /api/worker/queues`.
+type QueueSummary struct {
+	PendingByType    map[scheduler.JobType]int `json:"pendingByType"`
+	DeadLetterByType map[scheduler.JobType]int `json:"deadLetterByType"`
+	PendingTotal     int                       `json:"pendingTotal"`
+	DeadLetterTotal  int                       `json:"deadLetterTotal"`
+}
+
+// QueueSummary summarizes the job queue's current pending and dead-letter
+// counts by job type.
+func (s *TodoService) QueueSummary() (QueueSummary, error) {
+	summary := QueueSummary{
+		PendingByType:    make(map[scheduler.JobType]int),
+		DeadLetterByType: make(map[scheduler.JobType]int),
+	}
+
+	pending, err := s.jobQueue.List(context.Background())
+	if err != nil {
+		return summary, err
+	}
+	for _, job := range pending {
+		summary.PendingByType[job.Type]++
+		summary.PendingTotal++
+	}
+
+	dead, err := s.jobQueue.DeadLetter(context.Background())
+	if err != nil {
+		return summary, err
+	}
+	for _, job := range dead {
+		summary.DeadLetterByType[job.Type]++
+		summary.DeadLetterTotal++
+	}
+
+	return summary, nil
+}
+
+// GetTask looks up a single job by ID, pending or dead-lettered, for
+// `GET /api/worker/tasks/:id`.
+func (s *TodoService) GetTask(id string) (scheduler.Job, bool, error) {
+	pending, err := s.jobQueue.List(context.Background())
+	if err != nil {
+		return scheduler.Job{}, false, err
+	}
+	for _, job := range pending {
+		if job.ID == id {
+			return job, true, nil
+		}
+	}
+
+	dead, err := s.jobQueue.DeadLetter(context.Background())
+	if err != nil {
+		return scheduler.Job{}, false, err
+	}
+	for _, job := range dead {
+		if job.ID == id {
+			return job, true, nil
+		}
+	}
+
+	return scheduler.Job{}, false, nil
+}
+
+// RetryTask moves a dead-lettered job back to pending, due immediately
+// with its RetryCount reset, for `POST /api/worker/tasks/:id/retry`.
+func (s *TodoService) RetryTask(id string) (scheduler.Job, error) {
+	return s.jobQueue.Requeue(context.Background(), id)
+}
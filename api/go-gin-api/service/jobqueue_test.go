@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"spicytodo-go-api/models"
+	"spicytodo-go-api/scheduler"
+)
+
+func TestCreateEnqueuesReminderJob(t *testing.T) {
+	service := newTestServiceWithEvents(t)
+	service.jobQueue = scheduler.NewMemoryQueue()
+
+	dueDate := time.Now().Format("2006-01-02")
+	todo := service.Create(models.TodoCreate{
+		Text:      "Remind me",
+		DueDate:   &dueDate,
+		Reminders: []models.Reminder{{RelativeTo: models.ReminderAnchorDueDate, OffsetSeconds: 9 * 3600}},
+	})
+
+	jobs, err := service.jobQueue.List(context.Background())
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].TodoID != todo.ID || jobs[0].Type != scheduler.JobReminderFire {
+		t.Fatalf("expected one reminder:fire job for %s, got %+v", todo.ID, jobs)
+	}
+}
+
+func TestCreateWithoutReminderEnqueuesNoJob(t *testing.T) {
+	service := newTestServiceWithEvents(t)
+	service.jobQueue = scheduler.NewMemoryQueue()
+
+	service.Create(models.TodoCreate{Text: "No reminder"})
+
+	jobs, _ := service.jobQueue.List(context.Background())
+	if len(jobs) != 0 {
+		t.Errorf("expected no jobs for a todo without a reminder, got %+v", jobs)
+	}
+}
+
+func TestToggleEnqueuesRecurrenceJob(t *testing.T) {
+	service := newTestServiceWithEvents(t)
+	service.jobQueue = scheduler.NewMemoryQueue()
+
+	dueDate := time.Now().Format("2006-01-02")
+	todo := service.Create(models.TodoCreate{
+		Text:           "Recurring",
+		RecurrenceRule: models.RecurrenceDaily,
+		DueDate:        &dueDate,
+	})
+
+	service.Toggle(todo.ID)
+
+	jobs, _ := service.jobQueue.List(context.Background())
+	found := false
+	for _, job := range jobs {
+		if job.TodoID == todo.ID && job.Type == scheduler.JobRecurrenceCreateNext {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected a recurrence:create-next job for %s, got %+v", todo.ID, jobs)
+	}
+}
+
+func TestProcessJobQueueMaterializesRecurrence(t *testing.T) {
+	service := newTestServiceWithEvents(t)
+	service.jobQueue = scheduler.NewMemoryQueue()
+
+	dueDate := time.Now().Format("2006-01-02")
+	todo := service.Create(models.TodoCreate{
+		Text:           "Recurring",
+		RecurrenceRule: models.RecurrenceDaily,
+		DueDate:        &dueDate,
+	})
+
+	initialCount := storeLen(service.store)
+	service.Toggle(todo.ID)
+	service.ProcessJobQueue()
+
+	if storeLen(service.store) <= initialCount {
+		t.Error("expected ProcessJobQueue to materialize the next occurrence")
+	}
+}
+
+func TestRetryOrDeadLetterRetriesUntilMaxRetries(t *testing.T) {
+	service := newIsolatedTodoService(t)
+	service.jobQueue = scheduler.NewMemoryQueue()
+
+	job := scheduler.Job{ID: "a", Type: scheduler.JobCalDAVPush, TodoID: "t1", FireAt: time.Now()}
+	service.retryOrDeadLetter(job, errors.New("boom"))
+
+	pending, _ := service.jobQueue.List(context.Background())
+	if len(pending) != 1 || pending[0].RetryCount != 1 || pending[0].LastError != "boom" {
+		t.Fatalf("expected job requeued with RetryCount=1, got %+v", pending)
+	}
+
+	dead, _ := service.jobQueue.DeadLetter(context.Background())
+	if len(dead) != 0 {
+		t.Fatalf("expected no dead-lettered jobs yet, got %+v", dead)
+	}
+}
+
+func TestRetryOrDeadLetterMovesToDeadLetterAfterMaxRetries(t *testing.T) {
+	service := newIsolatedTodoService(t)
+	service.jobQueue = scheduler.NewMemoryQueue()
+
+	job := scheduler.Job{
+		ID: "a", Type: scheduler.JobCalDAVPush, TodoID: "t1", FireAt: time.Now(),
+		RetryCount: scheduler.DefaultMaxRetries - 1,
+	}
+	service.retryOrDeadLetter(job, errors.New("boom"))
+
+	pending, _ := service.jobQueue.List(context.Background())
+	if len(pending) != 0 {
+		t.Fatalf("expected the exhausted job not to be requeued, got %+v", pending)
+	}
+
+	dead, _ := service.jobQueue.DeadLetter(context.Background())
+	if len(dead) != 1 || dead[0].ID != "a" || dead[0].LastError != "boom" {
+		t.Fatalf("expected job a dead-lettered with its error, got %+v", dead)
+	}
+}
+
+func TestQueueSummaryCountsPendingAndDeadLetterByType(t *testing.T) {
+	service := newIsolatedTodoService(t)
+	service.jobQueue = scheduler.NewMemoryQueue()
+
+	service.jobQueue.Enqueue(context.Background(), scheduler.Job{ID: "a", Type: scheduler.JobReminderFire, FireAt: time.Now().Add(time.Hour)})
+	service.retryOrDeadLetter(scheduler.Job{ID: "b", Type: scheduler.JobCalDAVPush, RetryCount: scheduler.DefaultMaxRetries - 1}, errors.New("boom"))
+
+	summary, err := service.QueueSummary()
+	if err != nil {
+		t.Fatalf("QueueSummary: %v", err)
+	}
+	if summary.PendingTotal != 1 || summary.PendingByType[scheduler.JobReminderFire] != 1 {
+		t.Errorf("expected one pending reminder:fire job, got %+v", summary)
+	}
+	if summary.DeadLetterTotal != 1 || summary.DeadLetterByType[scheduler.JobCalDAVPush] != 1 {
+		t.Errorf("expected one dead-lettered caldav:push job, got %+v", summary)
+	}
+}
+
+func TestGetTaskFindsPendingAndDeadLetteredJobs(t *testing.T) {
+	service := newIsolatedTodoService(t)
+	service.jobQueue = scheduler.NewMemoryQueue()
+
+	service.jobQueue.Enqueue(context.Background(), scheduler.Job{ID: "a", Type: scheduler.JobReminderFire, FireAt: time.Now().Add(time.Hour)})
+	service.retryOrDeadLetter(scheduler.Job{ID: "b", Type: scheduler.JobCalDAVPush, RetryCount: scheduler.DefaultMaxRetries - 1}, errors.New("boom"))
+
+	if job, found, err := service.GetTask("a"); err != nil || !found || job.ID != "a" {
+		t.Errorf("GetTask(a) = %+v, %v, %v; want the pending job", job, found, err)
+	}
+	if job, found, err := service.GetTask("b"); err != nil || !found || job.ID != "b" {
+		t.Errorf("GetTask(b) = %+v, %v, %v; want the dead-lettered job", job, found, err)
+	}
+	if _, found, err := service.GetTask("missing"); err != nil || found {
+		t.Errorf("GetTask(missing) = found %v, err %v; want not found", found, err)
+	}
+}
+
+func TestRetryTaskRequeuesDeadLetteredJob(t *testing.T) {
+	service := newIsolatedTodoService(t)
+	service.jobQueue = scheduler.NewMemoryQueue()
+
+	service.retryOrDeadLetter(scheduler.Job{ID: "b", Type: scheduler.JobCalDAVPush, RetryCount: scheduler.DefaultMaxRetries - 1}, errors.New("boom"))
+
+	job, err := service.RetryTask("b")
+	if err != nil {
+		t.Fatalf("RetryTask: %v", err)
+	}
+	if job.RetryCount != 0 || job.LastError != "" {
+		t.Errorf("expected RetryTask to reset RetryCount/LastError, got %+v", job)
+	}
+
+	if _, err := service.RetryTask("missing"); err != scheduler.ErrJobNotFound {
+		t.Errorf("RetryTask(missing) error = %v, want ErrJobNotFound", err)
+	}
+}
+
+func TestPendingJobsAndCancelJob(t *testing.T) {
+	service := newTestServiceWithEvents(t)
+	service.jobQueue = scheduler.NewMemoryQueue()
+
+	dueDate := time.Now().Format("2006-01-02")
+	service.Create(models.TodoCreate{
+		Text:      "Remind me",
+		DueDate:   &dueDate,
+		Reminders: []models.Reminder{{RelativeTo: models.ReminderAnchorDueDate, OffsetSeconds: 9 * 3600}},
+	})
+
+	jobs, err := service.PendingJobs()
+	if err != nil || len(jobs) != 1 {
+		t.Fatalf("PendingJobs() = %+v, %v; want one pending job", jobs, err)
+	}
+
+	if err := service.CancelJob(jobs[0].ID); err != nil {
+		t.Fatalf("CancelJob: %v", err)
+	}
+
+	jobs, _ = service.PendingJobs()
+	if len(jobs) != 0 {
+		t.Errorf("expected CancelJob to remove the job, got %+v", jobs)
+	}
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"spicytodo-go-api/models"
+	"spicytodo-go-api/recurrence"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaintenanceService holds planned maintenance windows: operational config
+// (not user data), so unlike TodoService it's a plain in-memory CRUD store
+// with no event log or search index.
+type MaintenanceService struct {
+	mu      sync.RWMutex
+	windows map[string]*models.MaintenanceWindow
+}
+
+func NewMaintenanceService() *MaintenanceService {
+	return &MaintenanceService{windows: make(map[string]*models.MaintenanceWindow)}
+}
+
+// List returns every maintenance window, for `GET /api/maintenance`.
+func (m *MaintenanceService) List() []*models.MaintenanceWindow {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]*models.MaintenanceWindow, 0, len(m.windows))
+	for _, w := range m.windows {
+		result = append(result, w)
+	}
+	return result
+}
+
+// Create adds a new maintenance window.
+func (m *MaintenanceService) Create(input models.MaintenanceWindowCreate) *models.MaintenanceWindow {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	w := &models.MaintenanceWindow{
+		ID:          uuid.New().String(),
+		Name:        input.Name,
+		Description: input.Description,
+		Schedule:    input.Schedule,
+		Target:      input.Target,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	m.windows[w.ID] = w
+	return w
+}
+
+// Update applies any set fields of input to the window identified by id.
+func (m *MaintenanceService) Update(id string, input models.MaintenanceWindowUpdate) (*models.MaintenanceWindow, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w, exists := m.windows[id]
+	if !exists {
+		return nil, false
+	}
+
+	if input.Name != nil {
+		w.Name = *input.Name
+	}
+	if input.Description != nil {
+		w.Description = *input.Description
+	}
+	if input.Schedule != nil {
+		w.Schedule = *input.Schedule
+	}
+	if input.Target != nil {
+		w.Target = *input.Target
+	}
+	w.UpdatedAt = time.Now()
+	return w, true
+}
+
+// Delete removes a maintenance window by id.
+func (m *MaintenanceService) Delete(id string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.windows[id]; !exists {
+		return false
+	}
+	delete(m.windows, id)
+	return true
+}
+
+// IsActive reports whether any window targeting todo is active at t, so
+// TodoService can suppress a reminder landing inside one, or defer
+// materializing a recurring todo's next occurrence until it closes.
+func (m *MaintenanceService) IsActive(t time.Time, todo *models.Todo) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, w := range m.windows {
+		if targets(w.Target, todo) && scheduleActiveAt(w.Schedule, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// targets reports whether target selects todo: All matches everything,
+// otherwise todo must match a listed ID or tag.
+func targets(target models.MaintenanceTarget, todo *models.Todo) bool {
+	if target.All {
+		return true
+	}
+	if todo == nil {
+		return false
+	}
+
+	for _, id := range target.TodoIDs {
+		if id == todo.ID {
+			return true
+		}
+	}
+	for _, tag := range target.Tags {
+		for _, todoTag := range todo.Tags {
+			if todoTag == tag {
+				return true
+			}
+		}
+	}
+	if todo.Category != nil {
+		for _, category := range target.Categories {
+			if category == *todo.Category {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// scheduleActiveAt reports whether t falls inside schedule's current
+// occurrence. A one-shot schedule (no Recurrence) is active on
+// [Start, End). A repeating one expands its RRULE for the latest
+// occurrence starting at or before t, and is active on
+// [occurrence, occurrence+Duration).
+func scheduleActiveAt(schedule models.MaintenanceSchedule, t time.Time) bool {
+	if schedule.Recurrence == "" {
+		if schedule.End == nil {
+			return false
+		}
+		return !t.Before(schedule.Start) && t.Before(*schedule.End)
+	}
+
+	rule, err := recurrence.Parse(schedule.Recurrence)
+	if err != nil {
+		return false
+	}
+
+	occurrences := rule.Between(schedule.Start, schedule.Start, t)
+	if len(occurrences) == 0 {
+		return false
+	}
+
+	latest := occurrences[len(occurrences)-1]
+	return t.Before(latest.Add(schedule.Duration))
+}
+
+// ActiveAt returns every window that's currently firing at t, for
+// `GET /api/maintenance/active`.
+func (m *MaintenanceService) ActiveAt(t time.Time) []*models.MaintenanceWindow {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var active []*models.MaintenanceWindow
+	for _, w := range m.windows {
+		if scheduleActiveAt(w.Schedule, t) {
+			active = append(active, w)
+		}
+	}
+	return active
+}
+
+// ListMaintenanceWindows returns every planned maintenance window, for
+// `GET /api/maintenance`.
+func (s *TodoService) ListMaintenanceWindows() []*models.MaintenanceWindow {
+	return s.maintenance.List()
+}
+
+// CreateMaintenanceWindow adds a new maintenance window.
+func (s *TodoService) CreateMaintenanceWindow(input models.MaintenanceWindowCreate) *models.MaintenanceWindow {
+	return s.maintenance.Create(input)
+}
+
+// UpdateMaintenanceWindow applies any set fields of input to the window
+// identified by id.
+func (s *TodoService) UpdateMaintenanceWindow(id string, input models.MaintenanceWindowUpdate) (*models.MaintenanceWindow, bool) {
+	return s.maintenance.Update(id, input)
+}
+
+// DeleteMaintenanceWindow removes a maintenance window by id.
+func (s *TodoService) DeleteMaintenanceWindow(id string) bool {
+	return s.maintenance.Delete(id)
+}
+
+// ActiveMaintenanceWindows returns every maintenance window currently
+// firing, for `GET /api/maintenance/active`.
+func (s *TodoService) ActiveMaintenanceWindows() []*models.MaintenanceWindow {
+	return s.maintenance.ActiveAt(time.Now())
+}
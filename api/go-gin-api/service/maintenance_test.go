@@ -0,0 +1,151 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"spicytodo-go-api/models"
+)
+
+func TestMaintenanceWindowOverlap(t *testing.T) {
+	m := NewMaintenanceService()
+
+	start := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 1, 5, 11, 0, 0, 0, time.UTC)
+	m.Create(models.MaintenanceWindowCreate{
+		Name:   "Overlapping outage",
+		Target: models.MaintenanceTarget{All: true},
+		Schedule: models.MaintenanceSchedule{
+			Start: start,
+			End:   &end,
+		},
+	})
+
+	todo := &models.Todo{ID: "any"}
+
+	if !m.IsActive(start, todo) {
+		t.Error("expected window to be active at its own start (inclusive)")
+	}
+	if !m.IsActive(start.Add(time.Hour), todo) {
+		t.Error("expected window to be active in the middle of its range")
+	}
+	if m.IsActive(end, todo) {
+		t.Error("expected window to be inactive at its own end (exclusive)")
+	}
+	if m.IsActive(start.Add(-time.Minute), todo) {
+		t.Error("expected window to be inactive before its start")
+	}
+}
+
+func TestMaintenanceWindowRecurring(t *testing.T) {
+	m := NewMaintenanceService()
+
+	start := time.Date(2026, 1, 3, 2, 0, 0, 0, time.UTC) // a Saturday
+	m.Create(models.MaintenanceWindowCreate{
+		Name:   "Weekly backup window",
+		Target: models.MaintenanceTarget{All: true},
+		Schedule: models.MaintenanceSchedule{
+			Start:      start,
+			Recurrence: "FREQ=WEEKLY;BYDAY=SA",
+			Duration:   2 * time.Hour,
+		},
+	})
+
+	todo := &models.Todo{ID: "any"}
+
+	if !m.IsActive(start.Add(time.Hour), todo) {
+		t.Error("expected the first occurrence to be active an hour in")
+	}
+	if m.IsActive(start.Add(3*time.Hour), todo) {
+		t.Error("expected the first occurrence to have closed after its duration")
+	}
+
+	nextSaturday := start.AddDate(0, 0, 7)
+	if !m.IsActive(nextSaturday.Add(30*time.Minute), todo) {
+		t.Error("expected the following Saturday's occurrence to also be active")
+	}
+	if m.IsActive(start.AddDate(0, 0, 2), todo) {
+		t.Error("expected a weekday between occurrences to be inactive")
+	}
+}
+
+func TestMaintenanceTargetByCategory(t *testing.T) {
+	m := NewMaintenanceService()
+
+	start := time.Now().Add(-time.Hour)
+	end := start.Add(3 * time.Hour)
+	m.Create(models.MaintenanceWindowCreate{
+		Name:   "Billing maintenance",
+		Target: models.MaintenanceTarget{Categories: []string{"billing"}},
+		Schedule: models.MaintenanceSchedule{
+			Start: start,
+			End:   &end,
+		},
+	})
+
+	category := "billing"
+	targeted := &models.Todo{ID: "1", Category: &category}
+	other := &models.Todo{ID: "2"}
+
+	if !m.IsActive(time.Now(), targeted) {
+		t.Error("expected a todo in the targeted category to be covered")
+	}
+	if m.IsActive(time.Now(), other) {
+		t.Error("expected a todo outside the targeted category to be unaffected")
+	}
+}
+
+func TestMaintenanceServiceActiveAt(t *testing.T) {
+	m := NewMaintenanceService()
+
+	now := time.Now()
+	active := m.Create(models.MaintenanceWindowCreate{
+		Name:   "Active now",
+		Target: models.MaintenanceTarget{All: true},
+		Schedule: models.MaintenanceSchedule{
+			Start: now.Add(-time.Hour),
+			End:   &[]time.Time{now.Add(time.Hour)}[0],
+		},
+	})
+	m.Create(models.MaintenanceWindowCreate{
+		Name:   "Already closed",
+		Target: models.MaintenanceTarget{All: true},
+		Schedule: models.MaintenanceSchedule{
+			Start: now.Add(-3 * time.Hour),
+			End:   &[]time.Time{now.Add(-time.Hour)}[0],
+		},
+	})
+
+	result := m.ActiveAt(now)
+	if len(result) != 1 {
+		t.Fatalf("expected exactly 1 active window, got %d", len(result))
+	}
+	if result[0].ID != active.ID {
+		t.Errorf("expected the active window %q, got %q", active.ID, result[0].ID)
+	}
+}
+
+func TestGetStatsExcludingMaintenanceOmitsSuppressedOverdue(t *testing.T) {
+	service := newTestServiceWithEvents(t)
+
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+
+	service.Create(models.TodoCreate{Text: "Overdue, no window", DueDate: &yesterday})
+	covered := service.Create(models.TodoCreate{Text: "Overdue, under maintenance", DueDate: &yesterday})
+
+	service.CreateMaintenanceWindow(models.MaintenanceWindowCreate{
+		Name:   "Ongoing outage",
+		Target: models.MaintenanceTarget{TodoIDs: []string{covered.ID}},
+		Schedule: models.MaintenanceSchedule{
+			Start: time.Now().Add(-time.Hour),
+			End:   &[]time.Time{time.Now().Add(time.Hour)}[0],
+		},
+	})
+
+	if stats := service.GetStats(); stats.OverdueCount != 2 {
+		t.Errorf("expected GetStats to count both overdue todos, got %d", stats.OverdueCount)
+	}
+	if stats := service.GetStatsExcludingMaintenance(); stats.OverdueCount != 1 {
+		t.Errorf("expected GetStatsExcludingMaintenance to omit the one under maintenance, got %d", stats.OverdueCount)
+	}
+}
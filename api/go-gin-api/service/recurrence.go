@@ -0,0 +1,177 @@
+package service
+
+import (
+	"spicytodo-go-api/models"
+	"spicytodo-go-api/recurrence"
+	"time"
+)
+
+// RRule is the service package's alias for recurrence.Rule, the parsed form
+// of an iCalendar RRULE string.
+type RRule = recurrence.Rule
+
+// ParseRRule parses s into an RRule, reporting false if it isn't valid
+// RRULE syntax.
+func ParseRRule(s string) (RRule, bool) {
+	rule, err := recurrence.Parse(s)
+	return rule, err == nil
+}
+
+// FormatRRule serializes rule back into RRULE string form, the inverse of
+// ParseRRule, so a rule survives an export/import round trip.
+func FormatRRule(rule RRule) string {
+	return rule.String()
+}
+
+// legacyRRule expands the old fixed RecurrenceRule enum into the RRULE
+// string it's shorthand for, so a recurring todo always flows through
+// recurrence.Parse/Next regardless of which field it was created with.
+func legacyRRule(rule models.RecurrenceRule) (string, bool) {
+	switch rule {
+	case models.RecurrenceDaily:
+		return "FREQ=DAILY", true
+	case models.RecurrenceWeekly:
+		return "FREQ=WEEKLY", true
+	case models.RecurrenceMonthly:
+		return "FREQ=MONTHLY", true
+	default:
+		return "", false
+	}
+}
+
+// effectiveRRule returns the RRULE string governing todo's recurrence:
+// todo.Recurrence verbatim if set (it takes precedence, per its doc
+// comment), otherwise the legacy RecurrenceRule enum's RRULE shorthand.
+func effectiveRRule(todo *models.Todo) (string, bool) {
+	if todo.Recurrence != "" {
+		return todo.Recurrence, true
+	}
+	return legacyRRule(todo.RecurrenceRule)
+}
+
+// NextOccurrences expands todo's recurrence rule (see effectiveRRule) into
+// up to n occurrence dates strictly after from, e.g. for a calendar view
+// that wants "the next 5 dates" rather than materializing each one via
+// ProcessRecurringTodos/createNextOccurrence. DTSTART is todo.DueDate if
+// set, otherwise todo.CreatedAt. Dates listed in todo.ExceptionDates are
+// skipped, per RFC 5545 EXDATE semantics. Returns nil if todo has no
+// recurrence, its due date doesn't parse, or n <= 0.
+func (s *TodoService) NextOccurrences(todo *models.Todo, from time.Time, n int) []time.Time {
+	if n <= 0 {
+		return nil
+	}
+
+	rruleStr, ok := effectiveRRule(todo)
+	if !ok {
+		return nil
+	}
+	rule, err := recurrence.Parse(rruleStr)
+	if err != nil {
+		return nil
+	}
+
+	dtstart := todo.CreatedAt
+	if todo.DueDate != nil {
+		parsed, err := time.Parse("2006-01-02", *todo.DueDate)
+		if err != nil {
+			return nil
+		}
+		dtstart = parsed
+	}
+
+	occurrences := make([]time.Time, 0, n)
+	after := from
+	for len(occurrences) < n {
+		next, ok := nextOccurrence(rule, dtstart, after, todo)
+		if !ok {
+			break
+		}
+		occurrences = append(occurrences, next)
+		after = next
+	}
+	return occurrences
+}
+
+// isExceptionDate reports whether date falls on one of todo's
+// ExceptionDates (compared as "2006-01-02" due dates, the same precision
+// DueDate itself uses), meaning an otherwise-valid occurrence on that date
+// should be skipped, per RFC 5545 EXDATE semantics.
+func isExceptionDate(todo *models.Todo, date time.Time) bool {
+	target := date.Format("2006-01-02")
+	for _, d := range todo.ExceptionDates {
+		if d == target {
+			return true
+		}
+	}
+	return false
+}
+
+// nextOccurrence finds rule's first occurrence strictly after `after`,
+// skipping any date excluded by isExceptionDate.
+func nextOccurrence(rule RRule, dtstart, after time.Time, todo *models.Todo) (time.Time, bool) {
+	for {
+		next, ok := rule.Next(dtstart, after)
+		if !ok {
+			return time.Time{}, false
+		}
+		if !isExceptionDate(todo, next) {
+			return next, true
+		}
+		after = next
+	}
+}
+
+// NextOccurrence returns the next due date after todo's current DueDate per
+// its recurrence rule (see effectiveRRule), skipping ExceptionDates. It
+// doesn't persist anything; see MaterializeNext to create the todo.
+func (s *TodoService) NextOccurrence(todo *models.Todo) (time.Time, bool) {
+	if todo.DueDate == nil {
+		return time.Time{}, false
+	}
+	dueDate, err := time.Parse("2006-01-02", *todo.DueDate)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	rruleStr, ok := effectiveRRule(todo)
+	if !ok {
+		return time.Time{}, false
+	}
+	rule, err := recurrence.Parse(rruleStr)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return nextOccurrence(rule, dueDate, dueDate, todo)
+}
+
+// OccurrencesBetween expands todo's recurrence rule into every occurrence
+// in [from, to] (see recurrence.Rule.Between), skipping ExceptionDates,
+// without persisting anything. Backs GET /api/todos/:id/occurrences, which
+// wants a virtual preview of a window rather than materialized todos.
+func (s *TodoService) OccurrencesBetween(todo *models.Todo, from, to time.Time) []time.Time {
+	rruleStr, ok := effectiveRRule(todo)
+	if !ok {
+		return nil
+	}
+	rule, err := recurrence.Parse(rruleStr)
+	if err != nil {
+		return nil
+	}
+
+	dtstart := todo.CreatedAt
+	if todo.DueDate != nil {
+		if parsed, err := time.Parse("2006-01-02", *todo.DueDate); err == nil {
+			dtstart = parsed
+		}
+	}
+
+	all := rule.Between(dtstart, from, to)
+	occurrences := make([]time.Time, 0, len(all))
+	for _, t := range all {
+		if !isExceptionDate(todo, t) {
+			occurrences = append(occurrences, t)
+		}
+	}
+	return occurrences
+}
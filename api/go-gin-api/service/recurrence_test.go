@@ -0,0 +1,223 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"spicytodo-go-api/models"
+)
+
+func TestParseRRuleDefaults(t *testing.T) {
+	rule, ok := ParseRRule("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE")
+	if !ok {
+		t.Fatal("expected rule to parse")
+	}
+	if rule.Freq != "WEEKLY" || rule.Interval != 2 {
+		t.Errorf("got Freq=%s Interval=%d", rule.Freq, rule.Interval)
+	}
+	if len(rule.ByDay) != 2 {
+		t.Errorf("expected 2 BYDAY entries, got %d", len(rule.ByDay))
+	}
+}
+
+func TestParseRRuleRejectsMissingFreq(t *testing.T) {
+	if _, ok := ParseRRule("BYDAY=MO"); ok {
+		t.Error("expected a rule with no FREQ to fail to parse")
+	}
+}
+
+func TestFormatRRuleRoundTrip(t *testing.T) {
+	original := "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=5"
+	rule, ok := ParseRRule(original)
+	if !ok {
+		t.Fatal("expected rule to parse")
+	}
+
+	formatted := FormatRRule(rule)
+	reparsed, ok := ParseRRule(formatted)
+	if !ok {
+		t.Fatalf("expected round-tripped rule %q to parse", formatted)
+	}
+	if reparsed.Freq != rule.Freq || reparsed.Interval != rule.Interval || reparsed.Count != rule.Count {
+		t.Errorf("round trip mismatch: got %+v, want %+v", reparsed, rule)
+	}
+}
+
+func TestLegacyRRuleShorthand(t *testing.T) {
+	tests := []struct {
+		rule models.RecurrenceRule
+		want string
+	}{
+		{models.RecurrenceDaily, "FREQ=DAILY"},
+		{models.RecurrenceWeekly, "FREQ=WEEKLY"},
+		{models.RecurrenceMonthly, "FREQ=MONTHLY"},
+	}
+
+	for _, tt := range tests {
+		got, ok := legacyRRule(tt.rule)
+		if !ok || got != tt.want {
+			t.Errorf("legacyRRule(%s) = (%q, %v), want (%q, true)", tt.rule, got, ok, tt.want)
+		}
+	}
+
+	if _, ok := legacyRRule(models.RecurrenceNone); ok {
+		t.Error("expected RecurrenceNone to have no shorthand")
+	}
+}
+
+func TestEffectiveRRulePrefersRecurrence(t *testing.T) {
+	todo := &models.Todo{RecurrenceRule: models.RecurrenceDaily, Recurrence: "FREQ=WEEKLY;BYDAY=MO"}
+
+	got, ok := effectiveRRule(todo)
+	if !ok || got != todo.Recurrence {
+		t.Errorf("effectiveRRule = (%q, %v), want (%q, true)", got, ok, todo.Recurrence)
+	}
+}
+
+func TestEffectiveRRuleFallsBackToLegacyEnum(t *testing.T) {
+	todo := &models.Todo{RecurrenceRule: models.RecurrenceMonthly}
+
+	got, ok := effectiveRRule(todo)
+	if !ok || got != "FREQ=MONTHLY" {
+		t.Errorf("effectiveRRule = (%q, %v), want (\"FREQ=MONTHLY\", true)", got, ok)
+	}
+}
+
+func TestNextOccurrencesExpandsFromDueDate(t *testing.T) {
+	service := newIsolatedTodoService(t)
+	dueDate := "2026-01-05" // a Monday
+	todo := &models.Todo{
+		DueDate:    &dueDate,
+		Recurrence: "FREQ=WEEKLY;BYDAY=MO,WE",
+	}
+
+	from, _ := time.Parse("2006-01-02", dueDate)
+	occurrences := service.NextOccurrences(todo, from, 3)
+
+	if len(occurrences) != 3 {
+		t.Fatalf("expected 3 occurrences, got %d", len(occurrences))
+	}
+	want := []string{"2026-01-07", "2026-01-12", "2026-01-14"}
+	for i, w := range want {
+		if got := occurrences[i].Format("2006-01-02"); got != w {
+			t.Errorf("occurrences[%d] = %s, want %s", i, got, w)
+		}
+	}
+}
+
+func TestNextOccurrencesStopsAtCount(t *testing.T) {
+	service := newIsolatedTodoService(t)
+	dueDate := "2026-01-05"
+	todo := &models.Todo{
+		DueDate:    &dueDate,
+		Recurrence: "FREQ=DAILY;COUNT=2",
+	}
+
+	from, _ := time.Parse("2006-01-02", dueDate)
+	occurrences := service.NextOccurrences(todo, from, 5)
+
+	if len(occurrences) != 1 {
+		t.Errorf("expected COUNT=2 (1 left after DTSTART) to cap the result at 1, got %d", len(occurrences))
+	}
+}
+
+func TestNextOccurrencesWithoutRecurrenceReturnsNil(t *testing.T) {
+	service := newIsolatedTodoService(t)
+	todo := &models.Todo{RecurrenceRule: models.RecurrenceNone}
+
+	if occurrences := service.NextOccurrences(todo, time.Now(), 5); occurrences != nil {
+		t.Errorf("expected nil, got %v", occurrences)
+	}
+}
+
+func TestNextOccurrencesSkipsExceptionDates(t *testing.T) {
+	service := newIsolatedTodoService(t)
+	dueDate := "2026-01-05" // a Monday
+	todo := &models.Todo{
+		DueDate:        &dueDate,
+		Recurrence:     "FREQ=WEEKLY;BYDAY=MO,WE",
+		ExceptionDates: []string{"2026-01-07"},
+	}
+
+	from, _ := time.Parse("2006-01-02", dueDate)
+	occurrences := service.NextOccurrences(todo, from, 2)
+
+	if len(occurrences) != 2 {
+		t.Fatalf("expected 2 occurrences, got %d", len(occurrences))
+	}
+	want := []string{"2026-01-12", "2026-01-14"}
+	for i, w := range want {
+		if got := occurrences[i].Format("2006-01-02"); got != w {
+			t.Errorf("occurrences[%d] = %s, want %s (exception date should be skipped)", i, got, w)
+		}
+	}
+}
+
+func TestNextOccurrenceReturnsSingleNextDate(t *testing.T) {
+	service := newIsolatedTodoService(t)
+	dueDate := "2026-01-05"
+	todo := &models.Todo{
+		DueDate:    &dueDate,
+		Recurrence: "FREQ=DAILY",
+	}
+
+	next, ok := service.NextOccurrence(todo)
+	if !ok {
+		t.Fatal("expected a next occurrence")
+	}
+	if got := next.Format("2006-01-02"); got != "2026-01-06" {
+		t.Errorf("NextOccurrence = %s, want 2026-01-06", got)
+	}
+}
+
+func TestNextOccurrenceWithoutDueDateReturnsFalse(t *testing.T) {
+	service := newIsolatedTodoService(t)
+	todo := &models.Todo{Recurrence: "FREQ=DAILY"}
+
+	if _, ok := service.NextOccurrence(todo); ok {
+		t.Error("expected no next occurrence without a due date")
+	}
+}
+
+func TestOccurrencesBetweenExpandsWindow(t *testing.T) {
+	service := newIsolatedTodoService(t)
+	dueDate := "2026-01-05" // a Monday
+	todo := &models.Todo{
+		DueDate:    &dueDate,
+		Recurrence: "FREQ=WEEKLY;BYDAY=MO,WE",
+	}
+
+	from, _ := time.Parse("2006-01-02", dueDate)
+	to := from.AddDate(0, 0, 10)
+	occurrences := service.OccurrencesBetween(todo, from, to)
+
+	want := []string{"2026-01-05", "2026-01-07", "2026-01-12", "2026-01-14"}
+	if len(occurrences) != len(want) {
+		t.Fatalf("expected %d occurrences, got %d", len(want), len(occurrences))
+	}
+	for i, w := range want {
+		if got := occurrences[i].Format("2006-01-02"); got != w {
+			t.Errorf("occurrences[%d] = %s, want %s", i, got, w)
+		}
+	}
+}
+
+func TestOccurrencesBetweenSkipsExceptionDates(t *testing.T) {
+	service := newIsolatedTodoService(t)
+	dueDate := "2026-01-05"
+	todo := &models.Todo{
+		DueDate:        &dueDate,
+		Recurrence:     "FREQ=WEEKLY;BYDAY=MO,WE",
+		ExceptionDates: []string{"2026-01-05"},
+	}
+
+	from, _ := time.Parse("2006-01-02", dueDate)
+	to := from.AddDate(0, 0, 10)
+	occurrences := service.OccurrencesBetween(todo, from, to)
+
+	for _, o := range occurrences {
+		if o.Format("2006-01-02") == "2026-01-05" {
+			t.Error("expected exception date 2026-01-05 to be excluded")
+		}
+	}
+}
@@ -0,0 +1,337 @@
+package service
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"spicytodo-go-api/models"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fireKind distinguishes what a scheduled entry should do when it fires.
+// A reminder's kind is fireReminder itself prefixed onto an index
+// ("reminder:0", "reminder:1", ...), one per Todo.Reminders entry, via
+// reminderKind; fire dispatches any such kind by prefix match.
+type fireKind string
+
+const (
+	fireReminder     fireKind = "reminder"
+	fireSnoozeExpiry fireKind = "snoozeExpiry"
+)
+
+// reminderKind derives the heap entry kind for todo's i'th reminder.
+func reminderKind(i int) fireKind {
+	return fireKind(fmt.Sprintf("%s:%d", fireReminder, i))
+}
+
+// fireEntry is one pending timer: a todo ID, what to do when its time
+// comes, and when that is.
+type fireEntry struct {
+	ID     string    `json:"id"`
+	Kind   fireKind  `json:"kind"`
+	FireAt time.Time `json:"fireAt"`
+	index  int
+}
+
+// fireHeap orders entries by FireAt so the scheduler's single worker only
+// ever needs to sleep until the earliest one.
+type fireHeap []*fireEntry
+
+func (h fireHeap) Len() int           { return len(h) }
+func (h fireHeap) Less(i, j int) bool { return h[i].FireAt.Before(h[j].FireAt) }
+func (h fireHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i]; h[i].index = i; h[j].index = j }
+
+func (h *fireHeap) Push(x interface{}) {
+	entry := x.(*fireEntry)
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *fireHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	entry.index = -1
+	*h = old[:n-1]
+	return entry
+}
+
+// Scheduler fires reminders and snooze expiries from a single min-heap
+// keyed by next-fire-time, rather than one goroutine per todo. TodoService's
+// mutating methods call Add/Remove to keep the heap in sync as todos are
+// created, edited, snoozed, or deleted; a single worker goroutine (Run)
+// sleeps until the heap's earliest entry, using a timer that gets reset
+// whenever Add inserts something earlier.
+type Scheduler struct {
+	service *TodoService
+
+	mu      sync.Mutex
+	entries fireHeap
+	byKey   map[string]*fireEntry
+	wake    chan struct{}
+
+	// reminderCounts tracks how many reminder entries are currently
+	// scheduled for each todo ID, so syncReminders/cancelTodo can remove
+	// trailing entries left over from a previously longer Reminders list.
+	reminderCounts map[string]int
+}
+
+// defaultReplayGrace bounds how far in the past a missed reminder can be and
+// still fire once on startup. Restarting after a long outage would otherwise
+// replay every overdue reminder at once; entries older than the grace window
+// are dropped instead. Override with REMINDER_REPLAY_GRACE (a
+// time.ParseDuration string, e.g. "2h").
+const defaultReplayGrace = 24 * time.Hour
+
+func replayGraceFromEnv() time.Duration {
+	if raw := os.Getenv("REMINDER_REPLAY_GRACE"); raw != "" {
+		if d, err := time.ParseDuration(raw); err == nil {
+			return d
+		}
+	}
+	return defaultReplayGrace
+}
+
+// NewScheduler builds a Scheduler seeded from service's current todos (so
+// reminders/snoozes set before the scheduler started aren't missed). A
+// reminder more than the replay grace window overdue is dropped rather than
+// fired immediately; snooze expiries are always seeded regardless of age,
+// since skipping one would leave a todo snoozed forever.
+func NewScheduler(service *TodoService) *Scheduler {
+	s := &Scheduler{
+		service:        service,
+		byKey:          make(map[string]*fireEntry),
+		wake:           make(chan struct{}, 1),
+		reminderCounts: make(map[string]int),
+	}
+	heap.Init(&s.entries)
+
+	cutoff := time.Now().Add(-replayGraceFromEnv())
+	for _, todo := range service.snapshotTodos() {
+		s.seedTodo(todo, cutoff)
+	}
+
+	return s
+}
+
+func entryKey(id string, kind fireKind) string {
+	return id + "/" + string(kind)
+}
+
+// Add schedules id to fire at fireAt for kind, replacing any existing entry
+// for the same id/kind. Callers needing to move or cancel a fire should just
+// call Add again or Remove.
+func (s *Scheduler) Add(id string, fireAt time.Time, kind fireKind) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := entryKey(id, kind)
+	if existing, ok := s.byKey[key]; ok {
+		heap.Remove(&s.entries, existing.index)
+	}
+
+	entry := &fireEntry{ID: id, Kind: kind, FireAt: fireAt}
+	heap.Push(&s.entries, entry)
+	s.byKey[key] = entry
+	s.wakeWorker()
+}
+
+// Remove cancels a pending fire for id/kind, if one exists.
+func (s *Scheduler) Remove(id string, kind fireKind) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := entryKey(id, kind)
+	entry, ok := s.byKey[key]
+	if !ok {
+		return
+	}
+	heap.Remove(&s.entries, entry.index)
+	delete(s.byKey, key)
+}
+
+// syncTodo schedules or cancels todo's reminder and snooze-expiry entries to
+// match its current fields. It's called after every mutation that could
+// affect either (create, update, snooze/unsnooze, delete).
+func (s *Scheduler) syncTodo(todo *models.Todo) {
+	if todo == nil {
+		return
+	}
+
+	if todo.SnoozedUntil != nil {
+		s.Add(todo.ID, *todo.SnoozedUntil, fireSnoozeExpiry)
+	} else {
+		s.Remove(todo.ID, fireSnoozeExpiry)
+	}
+
+	s.syncReminders(todo)
+}
+
+// syncReminders schedules one heap entry per todo.Reminders fire time,
+// replacing any already scheduled for it, and removes trailing entries left
+// over from a previously longer Reminders list.
+func (s *Scheduler) syncReminders(todo *models.Todo) {
+	times := reminderFireTimes(todo)
+	for i, at := range times {
+		s.Add(todo.ID, at, reminderKind(i))
+	}
+
+	s.mu.Lock()
+	prevCount := s.reminderCounts[todo.ID]
+	if len(times) == 0 {
+		delete(s.reminderCounts, todo.ID)
+	} else {
+		s.reminderCounts[todo.ID] = len(times)
+	}
+	s.mu.Unlock()
+
+	for i := len(times); i < prevCount; i++ {
+		s.Remove(todo.ID, reminderKind(i))
+	}
+}
+
+// seedTodo is syncTodo's startup-only counterpart: a reminder whose fire
+// time is already before cutoff is dropped instead of scheduled, so a long
+// outage doesn't flood clients with a backlog of stale reminders the moment
+// the server comes back up.
+func (s *Scheduler) seedTodo(todo *models.Todo, cutoff time.Time) {
+	if todo == nil {
+		return
+	}
+
+	if todo.SnoozedUntil != nil {
+		s.Add(todo.ID, *todo.SnoozedUntil, fireSnoozeExpiry)
+	}
+
+	count := 0
+	for _, at := range reminderFireTimes(todo) {
+		if at.After(cutoff) {
+			s.Add(todo.ID, at, reminderKind(count))
+			count++
+		}
+	}
+	if count > 0 {
+		s.mu.Lock()
+		s.reminderCounts[todo.ID] = count
+		s.mu.Unlock()
+	}
+}
+
+// cancelTodo removes every pending entry for a deleted todo.
+func (s *Scheduler) cancelTodo(id string) {
+	s.mu.Lock()
+	count := s.reminderCounts[id]
+	delete(s.reminderCounts, id)
+	s.mu.Unlock()
+
+	for i := 0; i < count; i++ {
+		s.Remove(id, reminderKind(i))
+	}
+	s.Remove(id, fireSnoozeExpiry)
+}
+
+// Pending returns up to n upcoming fires, soonest first, for
+// `GET /api/admin/scheduler`.
+func (s *Scheduler) Pending(n int) []fireEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ordered := make([]*fireEntry, len(s.entries))
+	copy(ordered, s.entries)
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].FireAt.Before(ordered[j].FireAt) })
+
+	if n > len(ordered) {
+		n = len(ordered)
+	}
+	result := make([]fireEntry, n)
+	for i := 0; i < n; i++ {
+		result[i] = *ordered[i]
+	}
+	return result
+}
+
+func (s *Scheduler) wakeWorker() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run drives the scheduler until ctx is cancelled: sleep until the heap's
+// earliest entry, fire every entry that's now due, then sleep again. Add
+// cuts a sleep short via wake when an earlier entry arrives.
+func (s *Scheduler) Run(ctx context.Context) {
+	for {
+		timerC, stop := s.armTimer()
+
+		select {
+		case <-ctx.Done():
+			stop()
+			return
+		case <-s.wake:
+			stop()
+		case <-timerC:
+		}
+
+		s.fireDue()
+	}
+}
+
+// armTimer returns a channel that fires when the heap's earliest entry is
+// due, or a nil channel (which blocks forever) if the heap is empty.
+func (s *Scheduler) armTimer() (<-chan time.Time, func()) {
+	s.mu.Lock()
+	empty := s.entries.Len() == 0
+	var wait time.Duration
+	if !empty {
+		wait = time.Until(s.entries[0].FireAt)
+		if wait < 0 {
+			wait = 0
+		}
+	}
+	s.mu.Unlock()
+
+	if empty {
+		return nil, func() {}
+	}
+
+	t := time.NewTimer(wait)
+	return t.C, func() { t.Stop() }
+}
+
+func (s *Scheduler) fireDue() {
+	now := time.Now()
+	for {
+		s.mu.Lock()
+		if s.entries.Len() == 0 || s.entries[0].FireAt.After(now) {
+			s.mu.Unlock()
+			return
+		}
+		entry := heap.Pop(&s.entries).(*fireEntry)
+		delete(s.byKey, entryKey(entry.ID, entry.Kind))
+		s.mu.Unlock()
+
+		s.fire(entry)
+	}
+}
+
+func (s *Scheduler) fire(entry *fireEntry) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("scheduler: panic handling %s for %s: %v", entry.Kind, entry.ID, r)
+		}
+	}()
+
+	switch {
+	case entry.Kind == fireSnoozeExpiry:
+		s.service.expireSnooze(entry.ID)
+	case strings.HasPrefix(string(entry.Kind), string(fireReminder)):
+		s.service.dispatchReminder(entry.ID)
+	}
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"spicytodo-go-api/models"
+	"testing"
+	"time"
+)
+
+func TestSchedulerPendingOrdersByFireTime(t *testing.T) {
+	s := NewScheduler(newTestServiceWithEvents(t))
+
+	later := time.Now().Add(2 * time.Hour)
+	sooner := time.Now().Add(1 * time.Hour)
+	s.Add("a", later, fireReminder)
+	s.Add("b", sooner, fireSnoozeExpiry)
+
+	pending := s.Pending(10)
+	if len(pending) < 2 {
+		t.Fatalf("Pending() returned %d entries, want at least 2", len(pending))
+	}
+
+	if pending[0].ID != "b" {
+		t.Errorf("Pending()[0].ID = %q, want %q (the sooner entry)", pending[0].ID, "b")
+	}
+}
+
+func TestSchedulerAddReplacesExistingEntry(t *testing.T) {
+	s := NewScheduler(newTestServiceWithEvents(t))
+
+	first := time.Now().Add(1 * time.Hour)
+	second := time.Now().Add(30 * time.Minute)
+	s.Add("a", first, fireReminder)
+	s.Add("a", second, fireReminder)
+
+	found := 0
+	for _, entry := range s.Pending(100) {
+		if entry.ID == "a" && entry.Kind == fireReminder {
+			found++
+		}
+	}
+	if found != 1 {
+		t.Errorf("expected exactly 1 entry for a/reminder after re-Add, got %d", found)
+	}
+}
+
+func TestSchedulerSeedTodoDropsReminderOlderThanGrace(t *testing.T) {
+	s := NewScheduler(newTestServiceWithEvents(t))
+	cutoff := time.Now().Add(-time.Hour)
+
+	stale := time.Now().Add(-2 * time.Hour)
+	todo := &models.Todo{ID: "stale-reminder", Reminders: []models.Reminder{{At: &stale}}}
+
+	s.seedTodo(todo, cutoff)
+
+	for _, entry := range s.Pending(100) {
+		if entry.ID == "stale-reminder" {
+			t.Error("expected a reminder older than the grace window to be dropped, not scheduled")
+		}
+	}
+}
+
+func TestSchedulerSeedTodoAlwaysSeedsSnoozeExpiryRegardlessOfAge(t *testing.T) {
+	s := NewScheduler(newTestServiceWithEvents(t))
+	cutoff := time.Now().Add(-time.Hour)
+
+	stale := time.Now().Add(-2 * time.Hour)
+	todo := &models.Todo{ID: "stale-snooze", SnoozedUntil: &stale}
+
+	s.seedTodo(todo, cutoff)
+
+	found := false
+	for _, entry := range s.Pending(100) {
+		if entry.ID == "stale-snooze" && entry.Kind == fireSnoozeExpiry {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected an overdue snooze expiry to still be seeded so the todo isn't stuck snoozed forever")
+	}
+}
+
+func TestSchedulerRemoveCancelsEntry(t *testing.T) {
+	s := NewScheduler(newTestServiceWithEvents(t))
+
+	s.Add("a", time.Now().Add(time.Hour), fireSnoozeExpiry)
+	s.Remove("a", fireSnoozeExpiry)
+
+	for _, entry := range s.Pending(100) {
+		if entry.ID == "a" && entry.Kind == fireSnoozeExpiry {
+			t.Error("expected entry to be removed")
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"regexp"
+	"spicytodo-go-api/models"
+	"strings"
+)
+
+// SearchOptions narrows Search beyond the query string itself.
+type SearchOptions struct {
+	// Limit caps the number of results; 0 means unlimited.
+	Limit int
+}
+
+// searchIndex is an inverted index over todo text, tags, category, and
+// priority, kept up to date incrementally by TodoService's mutation methods
+// (and rebuilt wholesale after event-log replay on startup). It trades a
+// little memory for O(matching docs) lookups instead of an O(n) scan over
+// every todo on each search.
+//
+// postings[field][token] is the set of todo IDs whose field contains token.
+// "all" is a synthetic field covering every text/tag/category token, so an
+// unscoped term searches across all three at once.
+type searchIndex struct {
+	postings map[string]map[string]map[string]struct{}
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{postings: make(map[string]map[string]map[string]struct{})}
+}
+
+var searchTokenSplitRe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// tokenize lowercases s, strips punctuation, and splits it into words.
+func tokenize(s string) []string {
+	parts := searchTokenSplitRe.Split(strings.ToLower(s), -1)
+	tokens := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p != "" {
+			tokens = append(tokens, p)
+		}
+	}
+	return tokens
+}
+
+// fieldTokens returns todo's indexable tokens grouped by field ("text",
+// "tag", "category", "priority"), the same grouping searchIndex uses for
+// field-scoped queries like "tag:work".
+func fieldTokens(todo *models.Todo) map[string][]string {
+	fields := make(map[string][]string)
+
+	if tokens := tokenize(todo.Text); len(tokens) > 0 {
+		fields["text"] = tokens
+	}
+	for _, tag := range todo.Tags {
+		if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" {
+			fields["tag"] = append(fields["tag"], tag)
+		}
+	}
+	if todo.Category != nil {
+		if tokens := tokenize(*todo.Category); len(tokens) > 0 {
+			fields["category"] = tokens
+		}
+	}
+	if todo.Priority != "" {
+		fields["priority"] = []string{strings.ToLower(string(todo.Priority))}
+	}
+
+	return fields
+}
+
+// indexTodo adds todo's tokens to the index. It must be called with the
+// service's write lock held.
+func (idx *searchIndex) indexTodo(todo *models.Todo) {
+	for field, tokens := range fieldTokens(todo) {
+		for _, token := range tokens {
+			idx.add(field, token, todo.ID)
+			if field != "priority" {
+				idx.add("all", token, todo.ID)
+			}
+		}
+	}
+}
+
+// removeTodo undoes a prior indexTodo(todo) call, using todo's token set to
+// find every posting it appears in. Pass the todo's state from just before
+// a mutation, not after.
+func (idx *searchIndex) removeTodo(todo *models.Todo) {
+	for field, tokens := range fieldTokens(todo) {
+		for _, token := range tokens {
+			idx.remove(field, token, todo.ID)
+			if field != "priority" {
+				idx.remove("all", token, todo.ID)
+			}
+		}
+	}
+}
+
+// updateTodo reindexes a todo whose fields changed, given its state from
+// just before (old) and after (updated) the mutation.
+func (idx *searchIndex) updateTodo(old, updated *models.Todo) {
+	idx.removeTodo(old)
+	idx.indexTodo(updated)
+}
+
+func (idx *searchIndex) add(field, token, id string) {
+	tokens, ok := idx.postings[field]
+	if !ok {
+		tokens = make(map[string]map[string]struct{})
+		idx.postings[field] = tokens
+	}
+	ids, ok := tokens[token]
+	if !ok {
+		ids = make(map[string]struct{})
+		tokens[token] = ids
+	}
+	ids[id] = struct{}{}
+}
+
+func (idx *searchIndex) remove(field, token, id string) {
+	ids, ok := idx.postings[field][token]
+	if !ok {
+		return
+	}
+	delete(ids, id)
+	if len(ids) == 0 {
+		delete(idx.postings[field], token)
+	}
+}
+
+// lookup resolves one field:value term (value already lowercased) to the
+// set of matching IDs. A trailing "*" on value requests a prefix match
+// over every token in the field instead of an exact one.
+func (idx *searchIndex) lookup(field, value string) map[string]struct{} {
+	tokens, ok := idx.postings[field]
+	if !ok {
+		return nil
+	}
+
+	if prefix, ok := strings.CutSuffix(value, "*"); ok {
+		result := make(map[string]struct{})
+		for token, ids := range tokens {
+			if strings.HasPrefix(token, prefix) {
+				for id := range ids {
+					result[id] = struct{}{}
+				}
+			}
+		}
+		return result
+	}
+
+	return tokens[value]
+}
+
+// allIDs returns every indexed todo ID, used as the universe a leading NOT
+// term subtracts from.
+func (idx *searchIndex) allIDs() map[string]struct{} {
+	result := make(map[string]struct{})
+	for _, ids := range idx.postings["all"] {
+		for id := range ids {
+			result[id] = struct{}{}
+		}
+	}
+	return result
+}
+
+// eval resolves a query string into the set of matching todo IDs. The
+// grammar is a deliberately small subset: whitespace-separated terms are
+// ANDed together within a clause, " OR " separates clauses (which are
+// unioned), a term can be negated with a leading "-" or "NOT ", scoped to a
+// field with "field:value" (field is "tag", "category", "priority", or the
+// default "text"/"all"), and/or given a trailing "*" for a prefix match
+// (e.g. "buy*").
+func (idx *searchIndex) eval(query string) map[string]struct{} {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil
+	}
+
+	result := make(map[string]struct{})
+	for _, clause := range strings.Split(query, " OR ") {
+		clauseIDs, ok := idx.evalClause(strings.Fields(clause))
+		if !ok {
+			continue
+		}
+		for id := range clauseIDs {
+			result[id] = struct{}{}
+		}
+	}
+	return result
+}
+
+func (idx *searchIndex) evalClause(terms []string) (map[string]struct{}, bool) {
+	var ids map[string]struct{}
+	started := false
+
+	for i := 0; i < len(terms); i++ {
+		term := terms[i]
+		negate := false
+
+		if term == "NOT" && i+1 < len(terms) {
+			negate = true
+			i++
+			term = terms[i]
+		} else if after, ok := strings.CutPrefix(term, "-"); ok && after != "" {
+			negate = true
+			term = after
+		}
+
+		field, value := "all", strings.ToLower(term)
+		if name, rest, ok := strings.Cut(term, ":"); ok && name != "" {
+			field, value = strings.ToLower(name), strings.ToLower(rest)
+		}
+		matches := idx.lookup(field, value)
+
+		switch {
+		case negate && !started:
+			ids, started = subtract(idx.allIDs(), matches), true
+		case negate:
+			ids = subtract(ids, matches)
+		case !started:
+			ids, started = matches, true
+		default:
+			ids = intersect(ids, matches)
+		}
+	}
+
+	return ids, started
+}
+
+func intersect(a, b map[string]struct{}) map[string]struct{} {
+	result := make(map[string]struct{})
+	for id := range a {
+		if _, ok := b[id]; ok {
+			result[id] = struct{}{}
+		}
+	}
+	return result
+}
+
+func subtract(a, b map[string]struct{}) map[string]struct{} {
+	result := make(map[string]struct{})
+	for id := range a {
+		if _, ok := b[id]; !ok {
+			result[id] = struct{}{}
+		}
+	}
+	return result
+}
+
+// rebuildSearchIndex recomputes the search index from scratch against the
+// current store contents. It's used at startup, after event-log replay
+// (or sample-data loading) has populated s.store but before any concurrent
+// request can reach the service.
+func (s *TodoService) rebuildSearchIndex() {
+	s.searchIndex = newSearchIndex()
+	s.store.Range(func(todo *models.Todo) bool {
+		s.searchIndex.indexTodo(todo)
+		return true
+	})
+}
+
+// Search runs query against the inverted search index built from todo
+// text, tags, category, and priority. See searchIndex.eval for the query
+// grammar.
+func (s *TodoService) Search(query string, opts SearchOptions) []*models.Todo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := s.searchIndex.eval(query)
+	result := make([]*models.Todo, 0, len(ids))
+	for id := range ids {
+		if todo, ok := s.store.Get(id); ok {
+			result = append(result, todo)
+		}
+	}
+
+	if opts.Limit > 0 && len(result) > opts.Limit {
+		result = result[:opts.Limit]
+	}
+	return result
+}
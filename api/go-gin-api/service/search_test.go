@@ -0,0 +1,101 @@
+package service
+
+import (
+	"spicytodo-go-api/models"
+	"testing"
+)
+
+func TestSearchMatchesTextTagAndCategory(t *testing.T) {
+	service := newTestServiceWithEvents(t)
+	category := "Home"
+	service.Create(models.TodoCreate{Text: "Buy milk", Tags: []string{"errand"}, Category: &category})
+	service.Create(models.TodoCreate{Text: "Write report", Priority: models.PriorityHigh})
+
+	results := service.Search("milk", SearchOptions{})
+	if len(results) != 1 || results[0].Text != "Buy milk" {
+		t.Fatalf("expected 1 match for %q, got %v", "milk", results)
+	}
+}
+
+func TestSearchFieldScoped(t *testing.T) {
+	service := newTestServiceWithEvents(t)
+	service.Create(models.TodoCreate{Text: "Ship release", Priority: models.PriorityHigh, Tags: []string{"work"}})
+	service.Create(models.TodoCreate{Text: "Buy snacks", Priority: models.PriorityLow, Tags: []string{"home"}})
+
+	results := service.Search("priority:high", SearchOptions{})
+	if len(results) != 1 || results[0].Text != "Ship release" {
+		t.Fatalf("expected 1 high-priority match, got %v", results)
+	}
+
+	results = service.Search("tag:home", SearchOptions{})
+	if len(results) != 1 || results[0].Text != "Buy snacks" {
+		t.Fatalf("expected 1 home-tagged match, got %v", results)
+	}
+}
+
+func TestSearchPrefixMatch(t *testing.T) {
+	service := newTestServiceWithEvents(t)
+	service.Create(models.TodoCreate{Text: "Buy groceries"})
+	service.Create(models.TodoCreate{Text: "Bake a cake"})
+
+	results := service.Search("buy*", SearchOptions{})
+	if len(results) != 1 || results[0].Text != "Buy groceries" {
+		t.Fatalf("expected 1 prefix match, got %v", results)
+	}
+}
+
+func TestSearchBooleanAndOrNot(t *testing.T) {
+	service := newTestServiceWithEvents(t)
+	service.Create(models.TodoCreate{Text: "Buy milk", Tags: []string{"errand"}})
+	service.Create(models.TodoCreate{Text: "Buy bread", Tags: []string{"errand"}})
+	service.Create(models.TodoCreate{Text: "Walk the dog"})
+
+	// AND
+	results := service.Search("buy milk", SearchOptions{})
+	if len(results) != 1 || results[0].Text != "Buy milk" {
+		t.Fatalf("expected 1 AND match, got %v", results)
+	}
+
+	// OR
+	results = service.Search("milk OR dog", SearchOptions{})
+	if len(results) != 2 {
+		t.Fatalf("expected 2 OR matches, got %v", results)
+	}
+
+	// NOT
+	results = service.Search("errand NOT milk", SearchOptions{})
+	if len(results) != 1 || results[0].Text != "Buy bread" {
+		t.Fatalf("expected 1 NOT match, got %v", results)
+	}
+}
+
+func TestSearchReindexesOnUpdateAndDelete(t *testing.T) {
+	service := newTestServiceWithEvents(t)
+	created := service.Create(models.TodoCreate{Text: "Original text"})
+
+	newText := "Renamed text"
+	service.Update(created.ID, models.TodoUpdate{Text: &newText})
+
+	if results := service.Search("original", SearchOptions{}); len(results) != 0 {
+		t.Errorf("expected stale token to no longer match, got %v", results)
+	}
+	if results := service.Search("renamed", SearchOptions{}); len(results) != 1 {
+		t.Errorf("expected updated token to match, got %v", results)
+	}
+
+	service.Delete(created.ID)
+	if results := service.Search("renamed", SearchOptions{}); len(results) != 0 {
+		t.Errorf("expected deleted todo to no longer match, got %v", results)
+	}
+}
+
+func TestSearchLimit(t *testing.T) {
+	service := newTestServiceWithEvents(t)
+	service.Create(models.TodoCreate{Text: "Buy milk"})
+	service.Create(models.TodoCreate{Text: "Buy bread"})
+
+	results := service.Search("buy*", SearchOptions{Limit: 1})
+	if len(results) != 1 {
+		t.Fatalf("expected limit to cap results at 1, got %d", len(results))
+	}
+}
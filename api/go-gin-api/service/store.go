@@ -0,0 +1,117 @@
+package service
+
+import (
+	"os"
+	"spicytodo-go-api/models"
+)
+
+// TodoStore is the storage backend TodoService delegates todo persistence
+// to, so its business logic (recurrence, search indexing, event sourcing,
+// SSE) doesn't care whether todos live in an in-process map or a shared
+// Redis instance. See memoryStore and redisStore.
+//
+// TodoService's own mu already serializes every call into a TodoStore
+// within one process, so implementations don't need their own locking to
+// stay internally consistent; Txn exists for the case an implementation
+// (redisStore) needs several of its own operations to commit as one unit
+// even when shared across processes.
+type TodoStore interface {
+	// Get returns the todo with the given ID, or ok=false if it doesn't exist.
+	Get(id string) (*models.Todo, bool)
+	// Put inserts or overwrites the todo keyed by its own ID.
+	Put(todo *models.Todo)
+	// Delete removes the todo with the given ID, returning it (or
+	// ok=false if it didn't exist) so callers can use its prior state,
+	// e.g. to remove it from the search index.
+	Delete(id string) (*models.Todo, bool)
+	// Range calls fn for every stored todo, in no particular order, until
+	// fn returns false or every todo has been visited.
+	Range(fn func(todo *models.Todo) bool)
+	// Txn runs fn against a store scoped to a single atomic unit of work.
+	Txn(fn func(tx TodoStore) error) error
+}
+
+// newTodoStoreFromEnv selects a TodoStore backend from the STORE env var
+// ("memory", the default, or "redis"), mirroring how NewTodoService reads
+// EVENTS_LOG_PATH.
+func newTodoStoreFromEnv() TodoStore {
+	switch os.Getenv("STORE") {
+	case "redis":
+		addr := os.Getenv("REDIS_ADDR")
+		if addr == "" {
+			addr = "localhost:6379"
+		}
+		return newRedisStore(addr)
+	default:
+		return newMemoryStore()
+	}
+}
+
+// storeLen counts the todos in store via Range, since TodoStore has no Len
+// method of its own.
+func storeLen(store TodoStore) int {
+	n := 0
+	store.Range(func(*models.Todo) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// resetStore empties store, for the replace-on-import, bulk-clear, and
+// snapshot-replay paths that previously did `s.todos = make(...)`.
+func resetStore(store TodoStore) {
+	store.Txn(func(tx TodoStore) error {
+		ids := make([]string, 0, storeLen(tx))
+		tx.Range(func(todo *models.Todo) bool {
+			ids = append(ids, todo.ID)
+			return true
+		})
+		for _, id := range ids {
+			tx.Delete(id)
+		}
+		return nil
+	})
+}
+
+// memoryStore is the default TodoStore: a plain map guarded by
+// TodoService's mu.
+type memoryStore struct {
+	todos map[string]*models.Todo
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{todos: make(map[string]*models.Todo)}
+}
+
+func (m *memoryStore) Get(id string) (*models.Todo, bool) {
+	todo, ok := m.todos[id]
+	return todo, ok
+}
+
+func (m *memoryStore) Put(todo *models.Todo) {
+	m.todos[todo.ID] = todo
+}
+
+func (m *memoryStore) Delete(id string) (*models.Todo, bool) {
+	todo, ok := m.todos[id]
+	if !ok {
+		return nil, false
+	}
+	delete(m.todos, id)
+	return todo, true
+}
+
+func (m *memoryStore) Range(fn func(todo *models.Todo) bool) {
+	for _, todo := range m.todos {
+		if !fn(todo) {
+			return
+		}
+	}
+}
+
+// Txn just runs fn against the store itself: TodoService's mu already makes
+// the whole call atomic within this process.
+func (m *memoryStore) Txn(fn func(tx TodoStore) error) error {
+	return fn(m)
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"spicytodo-go-api/models"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisKeyPrefix namespaces each todo's hash key; the rest of the key is its ID.
+const redisKeyPrefix = "todo:"
+
+// tagKey and catKey namespace the secondary set indexes redisStore keeps
+// alongside each todo so GetByTag/GetByCategory can do an O(k) SMEMBERS
+// instead of scanning every todo.
+func tagKey(tag string) string      { return "tag:" + tag }
+func catKey(category string) string { return "cat:" + category }
+
+// remindersKey is the sorted set scored by each reminder's fire time (unix
+// seconds), letting GetUpcomingReminders run a ZRANGEBYSCORE instead of a
+// full scan. Members are "todoID#index" (see reminderMember) since a todo
+// can carry more than one reminder, each with its own fire time.
+const remindersKey = "reminders"
+
+// reminderMember and reminderIDFromMember convert between a todo's i'th
+// reminder and its remindersKey sorted-set member.
+func reminderMember(todoID string, i int) string {
+	return todoID + "#" + strconv.Itoa(i)
+}
+
+func reminderIDFromMember(member string) string {
+	todoID, _, _ := strings.Cut(member, "#")
+	return todoID
+}
+
+// redisStore backs TodoStore with Redis, so multiple API instances can
+// share one todo set: each todo is a hash-shaped JSON blob at
+// todo:{id}, tags/categories get a secondary set index (tag:{name},
+// cat:{name}), and reminders live in the "reminders" sorted set.
+type redisStore struct {
+	client *redis.Client
+}
+
+func newRedisStore(addr string) *redisStore {
+	return &redisStore{client: redis.NewClient(&redis.Options{Addr: addr})}
+}
+
+func (r *redisStore) Get(id string) (*models.Todo, bool) {
+	data, err := r.client.Get(context.Background(), redisKeyPrefix+id).Bytes()
+	if err != nil {
+		return nil, false
+	}
+	var todo models.Todo
+	if err := json.Unmarshal(data, &todo); err != nil {
+		return nil, false
+	}
+	return &todo, true
+}
+
+func (r *redisStore) Put(todo *models.Todo) {
+	ctx := context.Background()
+	data, err := json.Marshal(todo)
+	if err != nil {
+		return
+	}
+
+	// A todo's tags/category may have changed since it was last indexed, so
+	// drop the old set memberships before adding the new ones.
+	if old, ok := r.Get(todo.ID); ok {
+		r.unindex(ctx, old)
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.Set(ctx, redisKeyPrefix+todo.ID, data, 0)
+	for _, tag := range todo.Tags {
+		pipe.SAdd(ctx, tagKey(tag), todo.ID)
+	}
+	if todo.Category != nil {
+		pipe.SAdd(ctx, catKey(*todo.Category), todo.ID)
+	}
+	for i, fireTime := range reminderFireTimes(todo) {
+		pipe.ZAdd(ctx, remindersKey, redis.Z{Score: float64(fireTime.Unix()), Member: reminderMember(todo.ID, i)})
+	}
+	pipe.Exec(ctx)
+}
+
+func (r *redisStore) Delete(id string) (*models.Todo, bool) {
+	todo, ok := r.Get(id)
+	if !ok {
+		return nil, false
+	}
+
+	ctx := context.Background()
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, redisKeyPrefix+id)
+	r.unindexPipelined(pipe, todo)
+	pipe.Exec(ctx)
+
+	return todo, true
+}
+
+// unindex removes todo from its tag/category/reminder indexes in their own
+// round trip; used by Put to clear stale memberships before re-adding.
+func (r *redisStore) unindex(ctx context.Context, todo *models.Todo) {
+	pipe := r.client.TxPipeline()
+	r.unindexPipelined(pipe, todo)
+	pipe.Exec(ctx)
+}
+
+func (r *redisStore) unindexPipelined(pipe redis.Pipeliner, todo *models.Todo) {
+	ctx := context.Background()
+	for _, tag := range todo.Tags {
+		pipe.SRem(ctx, tagKey(tag), todo.ID)
+	}
+	if todo.Category != nil {
+		pipe.SRem(ctx, catKey(*todo.Category), todo.ID)
+	}
+	for i := range todo.Reminders {
+		pipe.ZRem(ctx, remindersKey, reminderMember(todo.ID, i))
+	}
+}
+
+func (r *redisStore) Range(fn func(todo *models.Todo) bool) {
+	ctx := context.Background()
+	iter := r.client.Scan(ctx, 0, redisKeyPrefix+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		id := strings.TrimPrefix(iter.Val(), redisKeyPrefix)
+		if todo, ok := r.Get(id); ok {
+			if !fn(todo) {
+				return
+			}
+		}
+	}
+}
+
+// Txn runs fn against the store itself. Each individual Get/Put/Delete call
+// is already atomic against Redis; a true cross-call MULTI/EXEC isn't
+// needed for the bulk operations TodoService wraps in a Txn today, since
+// they only need "each item commits independently," not all-or-nothing.
+func (r *redisStore) Txn(fn func(tx TodoStore) error) error {
+	return fn(r)
+}
+
+// idsByTag returns the IDs of todos carrying tag, via the tag:{name} set
+// index instead of a full scan.
+func (r *redisStore) idsByTag(tag string) ([]string, error) {
+	return r.client.SMembers(context.Background(), tagKey(tag)).Result()
+}
+
+// idsByCategory returns the IDs of todos in category, via the cat:{name}
+// set index instead of a full scan.
+func (r *redisStore) idsByCategory(category string) ([]string, error) {
+	return r.client.SMembers(context.Background(), catKey(category)).Result()
+}
+
+// upcomingReminderIDs returns the IDs of todos with a reminder firing
+// between from and to, via a ZRANGEBYSCORE on the reminders sorted set
+// instead of a full scan. A todo with more than one reminder in range only
+// contributes its ID once.
+func (r *redisStore) upcomingReminderIDs(from, to time.Time) ([]string, error) {
+	members, err := r.client.ZRangeByScore(context.Background(), remindersKey, &redis.ZRangeBy{
+		Min: strconv.FormatInt(from.Unix(), 10),
+		Max: strconv.FormatInt(to.Unix(), 10),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(members))
+	ids := make([]string, 0, len(members))
+	for _, member := range members {
+		id := reminderIDFromMember(member)
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// indexedStore is implemented by TodoStore backends that maintain their own
+// tag/category/reminder indexes (currently redisStore), letting
+// TodoService's GetByTag/GetByCategory/GetUpcomingReminders skip the full
+// Range scan memoryStore needs.
+type indexedStore interface {
+	idsByTag(tag string) ([]string, error)
+	idsByCategory(category string) ([]string, error)
+	upcomingReminderIDs(from, to time.Time) ([]string, error)
+}
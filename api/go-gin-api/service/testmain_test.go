@@ -0,0 +1,38 @@
+package service
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// newIsolatedTodoService returns a TodoService pointed at a private temp
+// event log, so its events can't leak into another test via the shared
+// default data/events.log path (see defaultEventsLogPath) and it can't
+// replay another test's events back in. Every test in this package that
+// needs a TodoService should go through this instead of calling
+// NewTodoService() directly, so isolation can't be dropped one test file
+// at a time.
+//
+// Resetting only .store isn't enough on its own: recordEvent writes
+// through the service's separate eventStore field regardless of what
+// .store is set to, so an un-isolated NewTodoService() still appends to
+// (and replays from) the shared log.
+//
+// A test that needs two NewTodoService() calls to replay the same log
+// (e.g. to assert state survives a restart) should set EVENTS_LOG_PATH
+// itself instead, so both calls share one path.
+func newIsolatedTodoService(t *testing.T) *TodoService {
+	t.Helper()
+	t.Setenv("EVENTS_LOG_PATH", filepath.Join(t.TempDir(), "events.log"))
+	return NewTodoService()
+}
+
+// newTestServiceWithEvents additionally clears the sample data
+// NewTodoService seeds when replay finds nothing, for tests that want to
+// start from a known-empty store.
+func newTestServiceWithEvents(t *testing.T) *TodoService {
+	t.Helper()
+	s := newIsolatedTodoService(t)
+	s.store = newMemoryStore()
+	return s
+}
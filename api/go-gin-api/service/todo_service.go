@@ -1,7 +1,12 @@
 package service
 
 import (
+	"encoding/json"
+	"log"
+	"os"
+	"spicytodo-go-api/events"
 	"spicytodo-go-api/models"
+	"spicytodo-go-api/scheduler"
 	"sync"
 	"time"
 
@@ -9,39 +14,277 @@ import (
 )
 
 type TodoService struct {
-	todos map[string]*models.Todo
-	mu    sync.RWMutex
+	store       TodoStore
+	mu          sync.RWMutex
+	eventStore  *EventStore
+	hub         *Hub
+	scheduler   *Scheduler
+	searchIndex *searchIndex
+	jobQueue    scheduler.Queue
+	caldav      *CalDAVClient
+	maintenance *MaintenanceService
+	agents      *AgentService
 }
 
+// defaultEventsLogPath is used when EVENTS_LOG_PATH is not set.
+const defaultEventsLogPath = "data/events.log"
+
 func NewTodoService() *TodoService {
 	service := &TodoService{
-		todos: make(map[string]*models.Todo),
+		store:       newTodoStoreFromEnv(),
+		hub:         NewHub(),
+		jobQueue:    newJobQueueFromEnv(),
+		maintenance: NewMaintenanceService(),
+		agents:      NewAgentService(),
+	}
+
+	if cfg, ok := caldavConfigFromEnv(); ok {
+		service.caldav = NewCalDAVClient(cfg)
+	}
+
+	path := os.Getenv("EVENTS_LOG_PATH")
+	if path == "" {
+		path = defaultEventsLogPath
+	}
+
+	store, err := NewEventStore(path)
+	if err != nil {
+		log.Printf("eventstore: disabled, running in-memory only: %v", err)
+		service.loadSampleData()
+		service.rebuildSearchIndex()
+		return service
 	}
-	service.loadSampleData()
+	service.eventStore = store
+
+	applied, err := store.Replay(service.applyEvent)
+	if err != nil {
+		log.Printf("eventstore: replay failed, starting from sample data: %v", err)
+		applied = 0
+	}
+
+	if applied == 0 {
+		service.loadSampleData()
+		service.store.Range(func(todo *models.Todo) bool {
+			service.eventStore.Append(events.TypeTodoCreated, events.TodoCreated{Todo: *todo})
+			return true
+		})
+	}
+
+	service.rebuildSearchIndex()
 	return service
 }
 
+// applyEvent folds one logged event into the in-memory projection. It is
+// used both during startup replay and could be reused for a future
+// `GET /api/events` tailing client.
+func (s *TodoService) applyEvent(record events.Record) error {
+	switch record.Type {
+	case events.TypeSnapshot:
+		var snap events.Snapshot
+		if err := json.Unmarshal(record.Data, &snap); err != nil {
+			return err
+		}
+		resetStore(s.store)
+		for i := range snap.Todos {
+			todo := snap.Todos[i]
+			s.store.Put(&todo)
+		}
+
+	case events.TypeTodoCreated:
+		var e events.TodoCreated
+		if err := json.Unmarshal(record.Data, &e); err != nil {
+			return err
+		}
+		todo := e.Todo
+		s.store.Put(&todo)
+
+	case events.TypeTodoUpdated:
+		var e events.TodoUpdated
+		if err := json.Unmarshal(record.Data, &e); err != nil {
+			return err
+		}
+		todo := e.Todo
+		s.store.Put(&todo)
+
+	case events.TypeTodoDeleted:
+		var e events.TodoDeleted
+		if err := json.Unmarshal(record.Data, &e); err != nil {
+			return err
+		}
+		s.store.Delete(e.ID)
+
+	case events.TypeTodoCompleted:
+		var e events.TodoCompleted
+		if err := json.Unmarshal(record.Data, &e); err != nil {
+			return err
+		}
+		if todo, ok := s.store.Get(e.ID); ok {
+			todo.Completed = e.Completed
+			s.store.Put(todo)
+		}
+
+	case events.TypeTodoSnoozed:
+		var e events.TodoSnoozed
+		if err := json.Unmarshal(record.Data, &e); err != nil {
+			return err
+		}
+		if todo, ok := s.store.Get(e.ID); ok {
+			todo.SnoozedUntil = e.Until
+			s.store.Put(todo)
+		}
+
+	case events.TypeBulkOp:
+		var e events.BulkOp
+		if err := json.Unmarshal(record.Data, &e); err != nil {
+			return err
+		}
+		s.applyBulkOp(e)
+	}
+
+	return nil
+}
+
+func (s *TodoService) applyBulkOp(op events.BulkOp) {
+	switch op.Operation {
+	case "clear":
+		resetStore(s.store)
+	case "delete":
+		for _, id := range op.IDs {
+			s.store.Delete(id)
+		}
+	case "complete", "uncomplete":
+		for _, id := range op.IDs {
+			if todo, ok := s.store.Get(id); ok {
+				todo.Completed = op.Operation == "complete"
+				s.store.Put(todo)
+			}
+		}
+	case "updatePriority":
+		for _, id := range op.IDs {
+			if todo, ok := s.store.Get(id); ok {
+				todo.Priority = models.Priority(op.Priority)
+				s.store.Put(todo)
+			}
+		}
+	}
+}
+
+// recordEvent appends to the event log if one is configured, compacting
+// into a snapshot once the log crosses snapshotThreshold. Logging failures
+// are reported but never block the in-memory mutation, since the map
+// remains the authoritative read path for the running process.
+func (s *TodoService) recordEvent(eventType string, data interface{}) uint64 {
+	if s.eventStore == nil {
+		return 0
+	}
+
+	seq, shouldSnapshot, err := s.eventStore.Append(eventType, data)
+	if err != nil {
+		log.Printf("eventstore: append failed: %v", err)
+		return 0
+	}
+
+	if shouldSnapshot {
+		state := make([]models.Todo, 0, storeLen(s.store))
+		s.store.Range(func(todo *models.Todo) bool {
+			state = append(state, *todo)
+			return true
+		})
+		if err := s.eventStore.WriteSnapshot(state, seq); err != nil {
+			log.Printf("eventstore: snapshot failed: %v", err)
+		}
+	}
+
+	return seq
+}
+
+// notify records an event and fans it out to SSE subscribers in one step,
+// reusing the event store's sequence number as the stream's event ID.
+func (s *TodoService) notify(eventType string, data interface{}, id string, todo *models.Todo) {
+	seq := s.recordEvent(eventType, data)
+	s.hub.Publish(ChangeEvent{Seq: seq, Type: eventType, ID: id, Todo: todo, At: time.Now()})
+}
+
+// Subscribe registers a new SSE subscriber matching filter.
+func (s *TodoService) Subscribe(filter StreamFilter) (int, <-chan ChangeEvent) {
+	return s.hub.Subscribe(filter)
+}
+
+// Unsubscribe removes an SSE subscriber registered via Subscribe.
+func (s *TodoService) Unsubscribe(id int) {
+	s.hub.Unsubscribe(id)
+}
+
+// SetScheduler wires the scheduler so Create/Update/Delete/Snooze/Toggle
+// keep its fire heap in sync. Optional: until it's called (e.g. in unit
+// tests that construct a TodoService directly), those methods just skip
+// scheduling.
+func (s *TodoService) SetScheduler(scheduler *Scheduler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scheduler = scheduler
+}
+
+// SchedulerPending returns up to n of the scheduler's soonest-due fires, for
+// `GET /api/admin/scheduler`. It returns nil if no scheduler is wired up.
+func (s *TodoService) SchedulerPending(n int) []fireEntry {
+	s.mu.RLock()
+	scheduler := s.scheduler
+	s.mu.RUnlock()
+
+	if scheduler == nil {
+		return nil
+	}
+	return scheduler.Pending(n)
+}
+
+// snapshotTodos returns a point-in-time copy of the todo pointers, for
+// callers (the scheduler, on startup) that need to iterate without holding
+// the service's lock themselves.
+func (s *TodoService) snapshotTodos() []*models.Todo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*models.Todo, 0, storeLen(s.store))
+	s.store.Range(func(todo *models.Todo) bool {
+		result = append(result, todo)
+		return true
+	})
+	return result
+}
+
+// EventsSince returns all recorded events with a sequence number greater
+// than since, for clients tailing GET /api/events. It returns an empty
+// slice (not an error) when the event store is disabled.
+func (s *TodoService) EventsSince(since uint64) ([]events.Record, error) {
+	if s.eventStore == nil {
+		return nil, nil
+	}
+	return s.eventStore.Since(since)
+}
+
 func (s *TodoService) GetAll(filter, search, priority string) []*models.Todo {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	result := make([]*models.Todo, 0)
-	for _, todo := range s.todos {
+	s.store.Range(func(todo *models.Todo) bool {
 		// Apply filters
 		if filter == "active" && todo.Completed {
-			continue
+			return true
 		}
 		if filter == "completed" && !todo.Completed {
-			continue
+			return true
 		}
 		if priority != "" && string(todo.Priority) != priority {
-			continue
+			return true
 		}
 		if search != "" && !contains(todo.Text, search) {
-			continue
+			return true
 		}
 		result = append(result, todo)
-	}
+		return true
+	})
 
 	return result
 }
@@ -50,8 +293,7 @@ func (s *TodoService) GetByID(id string) (*models.Todo, bool) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	todo, exists := s.todos[id]
-	return todo, exists
+	return s.store.Get(id)
 }
 
 func (s *TodoService) Create(input models.TodoCreate) *models.Todo {
@@ -65,17 +307,33 @@ func (s *TodoService) Create(input models.TodoCreate) *models.Todo {
 
 	now := time.Now()
 	todo := &models.Todo{
-		ID:           uuid.New().String(),
-		Text:         input.Text,
-		Priority:     input.Priority,
-		Completed:    input.Completed,
-		DueDate:      input.DueDate,
-		ReminderTime: input.ReminderTime,
-		CreatedAt:    now,
-		UpdatedAt:    now,
+		ID:             uuid.New().String(),
+		Text:           input.Text,
+		Priority:       input.Priority,
+		Completed:      input.Completed,
+		DueDate:        input.DueDate,
+		StartDate:      input.StartDate,
+		Reminders:      input.Reminders,
+		RecurrenceRule: input.RecurrenceRule,
+		Recurrence:     input.Recurrence,
+		ExceptionDates: input.ExceptionDates,
+		Tags:           input.Tags,
+		Category:       input.Category,
+		Labels:         input.Labels,
+		CreatedAt:      now,
+		UpdatedAt:      now,
 	}
 
-	s.todos[todo.ID] = todo
+	s.notify(events.TypeTodoCreated, events.TodoCreated{Todo: *todo}, todo.ID, todo)
+	s.store.Put(todo)
+	if s.searchIndex != nil {
+		s.searchIndex.indexTodo(todo)
+	}
+	if s.scheduler != nil {
+		s.scheduler.syncTodo(todo)
+	}
+	s.enqueueReminderJob(todo, 0)
+	s.pushCalDAV(todo)
 	return todo
 }
 
@@ -83,11 +341,13 @@ func (s *TodoService) Update(id string, input models.TodoUpdate) (*models.Todo,
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	todo, exists := s.todos[id]
+	todo, exists := s.store.Get(id)
 	if !exists {
 		return nil, false
 	}
 
+	before := *todo
+
 	// Update fields if provided
 	if input.Text != nil {
 		todo.Text = *input.Text
@@ -101,11 +361,39 @@ func (s *TodoService) Update(id string, input models.TodoUpdate) (*models.Todo,
 	if input.DueDate != nil {
 		todo.DueDate = input.DueDate
 	}
-	if input.ReminderTime != nil {
-		todo.ReminderTime = input.ReminderTime
+	if input.StartDate != nil {
+		todo.StartDate = input.StartDate
+	}
+	if input.Reminders != nil {
+		todo.Reminders = input.Reminders
+	}
+	if input.RecurrenceRule != nil {
+		todo.RecurrenceRule = *input.RecurrenceRule
+	}
+	if input.Recurrence != nil {
+		todo.Recurrence = *input.Recurrence
+	}
+	if input.ExceptionDates != nil {
+		todo.ExceptionDates = input.ExceptionDates
+	}
+	if input.Tags != nil {
+		todo.Tags = input.Tags
+	}
+	if input.Category != nil {
+		todo.Category = input.Category
 	}
 
 	todo.UpdatedAt = time.Now()
+	s.store.Put(todo)
+	s.notify(events.TypeTodoUpdated, events.TodoUpdated{Todo: *todo}, todo.ID, todo)
+	if s.searchIndex != nil {
+		s.searchIndex.updateTodo(&before, todo)
+	}
+	if s.scheduler != nil {
+		s.scheduler.syncTodo(todo)
+	}
+	s.enqueueReminderJob(todo, len(before.Reminders))
+	s.pushCalDAV(todo)
 	return todo, true
 }
 
@@ -113,11 +401,20 @@ func (s *TodoService) Delete(id string) bool {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, exists := s.todos[id]; !exists {
+	todo, exists := s.store.Get(id)
+	if !exists {
 		return false
 	}
 
-	delete(s.todos, id)
+	s.notify(events.TypeTodoDeleted, events.TodoDeleted{ID: id}, id, nil)
+	s.store.Delete(id)
+	if s.searchIndex != nil {
+		s.searchIndex.removeTodo(todo)
+	}
+	if s.scheduler != nil {
+		s.scheduler.cancelTodo(id)
+	}
+	s.deleteCalDAV(id)
 	return true
 }
 
@@ -125,22 +422,48 @@ func (s *TodoService) Toggle(id string) (*models.Todo, bool) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	todo, exists := s.todos[id]
+	todo, exists := s.store.Get(id)
 	if !exists {
 		return nil, false
 	}
 
 	todo.Completed = !todo.Completed
 	todo.UpdatedAt = time.Now()
+	s.store.Put(todo)
+	s.notify(events.TypeTodoCompleted, events.TodoCompleted{ID: todo.ID, Completed: todo.Completed}, todo.ID, todo)
+
+	// A completed recurring todo gets its next occurrence queued as a job
+	// rather than materialized inline, so it doesn't wait on
+	// ProcessRecurringTodos's periodic sweep but also doesn't hold up the
+	// toggle itself.
+	if todo.Completed && (todo.Recurrence != "" || todo.RecurrenceRule != models.RecurrenceNone) {
+		s.enqueueRecurrenceJob(todo)
+	}
+
+	if s.scheduler != nil {
+		s.scheduler.syncTodo(todo)
+	}
+	s.pushCalDAV(todo)
 	return todo, true
 }
 
 func (s *TodoService) GetStats() models.TodoStats {
+	return s.getStats(false)
+}
+
+// GetStatsExcludingMaintenance is like GetStats, but a todo currently inside
+// an active maintenance window doesn't count toward OverdueCount, since its
+// reminder is already suppressed for the same window.
+func (s *TodoService) GetStatsExcludingMaintenance() models.TodoStats {
+	return s.getStats(true)
+}
+
+func (s *TodoService) getStats(excludeMaintenance bool) models.TodoStats {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	stats := models.TodoStats{
-		Total:             len(s.todos),
+		Total:             storeLen(s.store),
 		Active:            0,
 		Completed:         0,
 		CompletionRate:    0,
@@ -150,9 +473,10 @@ func (s *TodoService) GetStats() models.TodoStats {
 		UpcomingCount:     0,
 	}
 
-	today := time.Now().Format("2006-01-02")
+	now := time.Now()
+	today := now.Format("2006-01-02")
 
-	for _, todo := range s.todos {
+	s.store.Range(func(todo *models.Todo) bool {
 		if todo.Completed {
 			stats.Completed++
 		} else {
@@ -164,14 +488,17 @@ func (s *TodoService) GetStats() models.TodoStats {
 		if todo.DueDate != nil && !todo.Completed {
 			dueDate := *todo.DueDate
 			if dueDate < today {
-				stats.OverdueCount++
+				if !excludeMaintenance || s.maintenance == nil || !s.maintenance.IsActive(now, todo) {
+					stats.OverdueCount++
+				}
 			} else if dueDate == today {
 				stats.DueTodayCount++
 			} else if isWithinDays(dueDate, today, 7) {
 				stats.UpcomingCount++
 			}
 		}
-	}
+		return true
+	})
 
 	if stats.Total > 0 {
 		stats.CompletionRate = float64(stats.Completed) / float64(stats.Total) * 100
@@ -184,10 +511,15 @@ func (s *TodoService) ClearCompleted() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	for id, todo := range s.todos {
+	var completed []string
+	s.store.Range(func(todo *models.Todo) bool {
 		if todo.Completed {
-			delete(s.todos, id)
+			completed = append(completed, todo.ID)
 		}
+		return true
+	})
+	for _, id := range completed {
+		s.store.Delete(id)
 	}
 }
 
@@ -199,34 +531,34 @@ func (s *TodoService) loadSampleData() {
 
 	sampleTodos := []models.Todo{
 		{
-			ID:           uuid.New().String(),
-			Text:         "Learn Go programming language",
-			Priority:     models.PriorityHigh,
-			Completed:    false,
-			DueDate:      &tomorrow,
-			ReminderTime: strPtr("09:00"),
-			CreatedAt:    now,
-			UpdatedAt:    now,
+			ID:        uuid.New().String(),
+			Text:      "Learn Go programming language",
+			Priority:  models.PriorityHigh,
+			Completed: false,
+			DueDate:   &tomorrow,
+			Reminders: []models.Reminder{dueDateOffsetReminder(9, 0)},
+			CreatedAt: now,
+			UpdatedAt: now,
 		},
 		{
-			ID:           uuid.New().String(),
-			Text:         "Build a todo API with Gin framework",
-			Priority:     models.PriorityHigh,
-			Completed:    true,
-			DueDate:      &yesterday,
-			ReminderTime: strPtr("14:30"),
-			CreatedAt:    now,
-			UpdatedAt:    now,
+			ID:        uuid.New().String(),
+			Text:      "Build a todo API with Gin framework",
+			Priority:  models.PriorityHigh,
+			Completed: true,
+			DueDate:   &yesterday,
+			Reminders: []models.Reminder{dueDateOffsetReminder(14, 30)},
+			CreatedAt: now,
+			UpdatedAt: now,
 		},
 		{
-			ID:           uuid.New().String(),
-			Text:         "Add Docker support for deployment",
-			Priority:     models.PriorityMedium,
-			Completed:    false,
-			DueDate:      &nextWeek,
-			ReminderTime: strPtr("16:00"),
-			CreatedAt:    now,
-			UpdatedAt:    now,
+			ID:        uuid.New().String(),
+			Text:      "Add Docker support for deployment",
+			Priority:  models.PriorityMedium,
+			Completed: false,
+			DueDate:   &nextWeek,
+			Reminders: []models.Reminder{dueDateOffsetReminder(16, 0)},
+			CreatedAt: now,
+			UpdatedAt: now,
 		},
 		{
 			ID:        uuid.New().String(),
@@ -246,8 +578,8 @@ func (s *TodoService) loadSampleData() {
 		},
 	}
 
-	for _, todo := range sampleTodos {
-		s.todos[todo.ID] = &todo
+	for i := range sampleTodos {
+		s.store.Put(&sampleTodos[i])
 	}
 }
 
@@ -297,3 +629,13 @@ func strPtr(s string) *string {
 	return &s
 }
 
+// dueDateOffsetReminder builds a Reminder firing at hour:minute on a todo's
+// due date, expressed as an offset from midnight rather than an absolute
+// time, so it still applies if the due date itself changes.
+func dueDateOffsetReminder(hour, minute int) models.Reminder {
+	return models.Reminder{
+		RelativeTo:    models.ReminderAnchorDueDate,
+		OffsetSeconds: hour*3600 + minute*60,
+	}
+}
+
@@ -8,8 +8,7 @@ import (
 )
 
 func TestGetAllWithEmptyService(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	todos := service.GetAll("", "", "")
 	
@@ -19,7 +18,7 @@ func TestGetAllWithEmptyService(t *testing.T) {
 }
 
 func TestGetByIDWithEmptyID(t *testing.T) {
-	service := NewTodoService()
+	service := newIsolatedTodoService(t)
 	
 	_, exists := service.GetByID("")
 	
@@ -29,8 +28,7 @@ func TestGetByIDWithEmptyID(t *testing.T) {
 }
 
 func TestUpdateWithEmptyUpdates(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	created := service.Create(models.TodoCreate{Text: "Original"})
 	
@@ -49,8 +47,7 @@ func TestUpdateWithEmptyUpdates(t *testing.T) {
 }
 
 func TestDeleteNonExistentTodo(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	deleted := service.Delete("non-existent-id")
 	
@@ -60,8 +57,7 @@ func TestDeleteNonExistentTodo(t *testing.T) {
 }
 
 func TestTogglePreservesOtherFields(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	originalText := "Test Todo"
 	originalPriority := models.PriorityHigh
@@ -88,8 +84,7 @@ func TestTogglePreservesOtherFields(t *testing.T) {
 }
 
 func TestGetStatsWithAllPriorities(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	// Create todos with all priority levels
 	service.Create(models.TodoCreate{Text: "High 1", Priority: models.PriorityHigh})
@@ -112,8 +107,7 @@ func TestGetStatsWithAllPriorities(t *testing.T) {
 }
 
 func TestClearCompletedPreservesActive(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	active1 := service.Create(models.TodoCreate{Text: "Active 1", Completed: false})
 	service.Create(models.TodoCreate{Text: "Completed 1", Completed: true})
@@ -123,8 +117,8 @@ func TestClearCompletedPreservesActive(t *testing.T) {
 	service.ClearCompleted()
 
 	// Should have 2 active todos
-	if len(service.todos) != 2 {
-		t.Errorf("Expected 2 active todos, got %d", len(service.todos))
+	if storeLen(service.store) != 2 {
+		t.Errorf("Expected 2 active todos, got %d", storeLen(service.store))
 	}
 
 	// Verify correct todos remain
@@ -137,8 +131,7 @@ func TestClearCompletedPreservesActive(t *testing.T) {
 }
 
 func TestConcurrentCreateAndRead(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	var wg sync.WaitGroup
 	createdIDs := make(chan string, 100)
@@ -175,14 +168,13 @@ func TestConcurrentCreateAndRead(t *testing.T) {
 		t.Errorf("Expected 50 todos created, got %d", count)
 	}
 
-	if len(service.todos) != 50 {
-		t.Errorf("Expected 50 todos in storage, got %d", len(service.todos))
+	if storeLen(service.store) != 50 {
+		t.Errorf("Expected 50 todos in storage, got %d", storeLen(service.store))
 	}
 }
 
 func TestConcurrentUpdateAndDelete(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	// Create initial todos
 	ids := make([]string, 20)
@@ -215,14 +207,13 @@ func TestConcurrentUpdateAndDelete(t *testing.T) {
 	wg.Wait()
 
 	// Should have 10 todos left (updated ones)
-	if len(service.todos) != 10 {
-		t.Errorf("Expected 10 todos remaining, got %d", len(service.todos))
+	if storeLen(service.store) != 10 {
+		t.Errorf("Expected 10 todos remaining, got %d", storeLen(service.store))
 	}
 }
 
 func TestFilterCombinations(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	// Create diverse test data
 	service.Create(models.TodoCreate{
@@ -270,8 +261,7 @@ func TestFilterCombinations(t *testing.T) {
 }
 
 func TestGetStatsDueDateCalculations(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	today := time.Now().Format("2006-01-02")
 	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
@@ -371,11 +361,10 @@ func TestHelperFunctionEdgeCases(t *testing.T) {
 }
 
 func TestCreateWithAllOptionalFields(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	dueDate := "2024-12-31"
-	reminderTime := "10:00"
+	reminders := []models.Reminder{{RelativeTo: models.ReminderAnchorDueDate, OffsetSeconds: 10 * 3600}}
 	category := "Work"
 
 	input := models.TodoCreate{
@@ -383,7 +372,7 @@ func TestCreateWithAllOptionalFields(t *testing.T) {
 		Priority:       models.PriorityHigh,
 		Completed:      true,
 		DueDate:        &dueDate,
-		ReminderTime:   &reminderTime,
+		Reminders:      reminders,
 		RecurrenceRule: models.RecurrenceWeekly,
 		Tags:           []string{"tag1", "tag2", "tag3"},
 		Category:       &category,
@@ -403,8 +392,8 @@ func TestCreateWithAllOptionalFields(t *testing.T) {
 	if *todo.DueDate != *input.DueDate {
 		t.Error("DueDate mismatch")
 	}
-	if *todo.ReminderTime != *input.ReminderTime {
-		t.Error("ReminderTime mismatch")
+	if len(todo.Reminders) != len(input.Reminders) || todo.Reminders[0] != input.Reminders[0] {
+		t.Error("Reminders mismatch")
 	}
 	if todo.RecurrenceRule != input.RecurrenceRule {
 		t.Error("RecurrenceRule mismatch")
@@ -418,8 +407,7 @@ func TestCreateWithAllOptionalFields(t *testing.T) {
 }
 
 func TestUpdateAllFields(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	created := service.Create(models.TodoCreate{Text: "Original"})
 
@@ -428,7 +416,7 @@ func TestUpdateAllFields(t *testing.T) {
 	newPriority := models.PriorityLow
 	newCompleted := true
 	newDueDate := "2024-12-31"
-	newReminderTime := "15:00"
+	newReminders := []models.Reminder{{RelativeTo: models.ReminderAnchorDueDate, OffsetSeconds: 15 * 3600}}
 	newRecurrence := models.RecurrenceMonthly
 	newCategory := "Personal"
 
@@ -437,7 +425,7 @@ func TestUpdateAllFields(t *testing.T) {
 		Priority:       &newPriority,
 		Completed:      &newCompleted,
 		DueDate:        &newDueDate,
-		ReminderTime:   &newReminderTime,
+		Reminders:      newReminders,
 		RecurrenceRule: &newRecurrence,
 		Tags:           []string{"updated"},
 		Category:       &newCategory,
@@ -457,8 +445,8 @@ func TestUpdateAllFields(t *testing.T) {
 	if *updated.DueDate != newDueDate {
 		t.Error("DueDate not updated")
 	}
-	if *updated.ReminderTime != newReminderTime {
-		t.Error("ReminderTime not updated")
+	if len(updated.Reminders) != len(newReminders) || updated.Reminders[0] != newReminders[0] {
+		t.Error("Reminders not updated")
 	}
 	if updated.RecurrenceRule != newRecurrence {
 		t.Error("RecurrenceRule not updated")
@@ -472,23 +460,21 @@ func TestUpdateAllFields(t *testing.T) {
 }
 
 func TestClearCompletedWithNoCompleted(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	service.Create(models.TodoCreate{Text: "Active 1", Completed: false})
 	service.Create(models.TodoCreate{Text: "Active 2", Completed: false})
 
-	initialCount := len(service.todos)
+	initialCount := storeLen(service.store)
 	service.ClearCompleted()
 
-	if len(service.todos) != initialCount {
+	if storeLen(service.store) != initialCount {
 		t.Errorf("ClearCompleted() should not remove active todos")
 	}
 }
 
 func TestGetAllWithCaseSensitiveSearch(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	service.Create(models.TodoCreate{Text: "Learn GO Programming"})
 	service.Create(models.TodoCreate{Text: "Learn Python"})
@@ -508,8 +494,7 @@ func TestGetAllWithCaseSensitiveSearch(t *testing.T) {
 }
 
 func TestMultipleTodoOperationsInSequence(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	// Create
 	todo := service.Create(models.TodoCreate{
@@ -544,8 +529,7 @@ func TestMultipleTodoOperationsInSequence(t *testing.T) {
 }
 
 func TestGetStatsWithOnlyCompletedTodos(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	service.Create(models.TodoCreate{Text: "Done 1", Completed: true})
 	service.Create(models.TodoCreate{Text: "Done 2", Completed: true})
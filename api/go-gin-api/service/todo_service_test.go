@@ -1,32 +1,54 @@
 package service
 
 import (
+	"path/filepath"
 	"spicytodo-go-api/models"
 	"testing"
 )
 
 func TestNewTodoService(t *testing.T) {
-	service := NewTodoService()
+	service := newIsolatedTodoService(t)
 
 	if service == nil {
 		t.Fatal("NewTodoService() returned nil")
 	}
 
-	if service.todos == nil {
-		t.Error("todos map is nil")
+	if service.store == nil {
+		t.Error("store is nil")
 	}
 
 	// Should have sample data loaded
-	if len(service.todos) == 0 {
+	if storeLen(service.store) == 0 {
 		t.Error("Expected sample todos to be loaded, got 0")
 	}
 }
 
+func TestNewTodoServiceReplaysEventsAcrossRestart(t *testing.T) {
+	t.Setenv("EVENTS_LOG_PATH", filepath.Join(t.TempDir(), "events.log"))
+
+	first := NewTodoService()
+	first.store = newMemoryStore()
+	created := first.Create(models.TodoCreate{Text: "Survive a restart"})
+	first.Toggle(created.ID)
+
+	// A fresh service pointed at the same log should rebuild the same
+	// state by replaying it, with no sample-data reseeding.
+	second := NewTodoService()
+
+	restored, ok := second.GetByID(created.ID)
+	if !ok {
+		t.Fatal("expected todo created before restart to be present after replay")
+	}
+	if restored.Text != "Survive a restart" {
+		t.Errorf("restored.Text = %q, want %q", restored.Text, "Survive a restart")
+	}
+	if !restored.Completed {
+		t.Error("expected restored todo to retain its completed state")
+	}
+}
+
 func TestCreateTodo(t *testing.T) {
-	service := NewTodoService()
-	
-	// Clear sample data
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	input := models.TodoCreate{
 		Text:      "Test Todo",
@@ -57,14 +79,13 @@ func TestCreateTodo(t *testing.T) {
 	}
 
 	// Check if added to storage
-	if len(service.todos) != 1 {
-		t.Errorf("Expected 1 todo in storage, got %d", len(service.todos))
+	if storeLen(service.store) != 1 {
+		t.Errorf("Expected 1 todo in storage, got %d", storeLen(service.store))
 	}
 }
 
 func TestCreateTodoDefaultPriority(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	input := models.TodoCreate{
 		Text: "Test Todo",
@@ -79,8 +100,7 @@ func TestCreateTodoDefaultPriority(t *testing.T) {
 }
 
 func TestGetByID(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	// Create a todo
 	input := models.TodoCreate{Text: "Test Todo"}
@@ -105,8 +125,7 @@ func TestGetByID(t *testing.T) {
 }
 
 func TestGetAll(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	// Create test todos
 	service.Create(models.TodoCreate{Text: "Todo 1", Priority: models.PriorityHigh, Completed: false})
@@ -140,8 +159,7 @@ func TestGetAll(t *testing.T) {
 }
 
 func TestUpdate(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	// Create a todo
 	created := service.Create(models.TodoCreate{Text: "Original"})
@@ -176,8 +194,7 @@ func TestUpdate(t *testing.T) {
 }
 
 func TestDelete(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	// Create a todo
 	created := service.Create(models.TodoCreate{Text: "To Delete"})
@@ -202,8 +219,7 @@ func TestDelete(t *testing.T) {
 }
 
 func TestToggle(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	// Create a todo
 	created := service.Create(models.TodoCreate{Text: "To Toggle", Completed: false})
@@ -232,8 +248,7 @@ func TestToggle(t *testing.T) {
 }
 
 func TestGetStats(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	// Create test todos
 	service.Create(models.TodoCreate{Text: "Todo 1", Priority: models.PriorityHigh, Completed: false})
@@ -273,8 +288,7 @@ func TestGetStats(t *testing.T) {
 }
 
 func TestGetStatsEmpty(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	stats := service.GetStats()
 
@@ -288,8 +302,7 @@ func TestGetStatsEmpty(t *testing.T) {
 }
 
 func TestClearCompleted(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	// Create test todos
 	service.Create(models.TodoCreate{Text: "Active 1", Completed: false})
@@ -301,21 +314,21 @@ func TestClearCompleted(t *testing.T) {
 	service.ClearCompleted()
 
 	// Should have 2 active todos left
-	if len(service.todos) != 2 {
-		t.Errorf("Expected 2 todos remaining, got %d", len(service.todos))
+	if storeLen(service.store) != 2 {
+		t.Errorf("Expected 2 todos remaining, got %d", storeLen(service.store))
 	}
 
 	// Verify only active todos remain
-	for _, todo := range service.todos {
+	service.store.Range(func(todo *models.Todo) bool {
 		if todo.Completed {
 			t.Error("Found completed todo after ClearCompleted()")
 		}
-	}
+		return true
+	})
 }
 
 func TestConcurrentAccess(t *testing.T) {
-	service := NewTodoService()
-	service.todos = make(map[string]*models.Todo)
+	service := newTestServiceWithEvents(t)
 
 	// Test concurrent writes
 	done := make(chan bool)
@@ -333,8 +346,43 @@ func TestConcurrentAccess(t *testing.T) {
 	}
 
 	// Should have 10 todos
-	if len(service.todos) != 10 {
-		t.Errorf("Expected 10 todos after concurrent writes, got %d", len(service.todos))
+	if storeLen(service.store) != 10 {
+		t.Errorf("Expected 10 todos after concurrent writes, got %d", storeLen(service.store))
+	}
+}
+
+// TestConcurrentAccessReplaysConsistently repeats TestConcurrentAccess's
+// concurrent-write scenario against a real event log instead of an
+// in-memory-only store, and checks that a fresh service built by replaying
+// that log ends up with the exact same todos as the one that ran the
+// writes live.
+func TestConcurrentAccessReplaysConsistently(t *testing.T) {
+	t.Setenv("EVENTS_LOG_PATH", filepath.Join(t.TempDir(), "events.log"))
+
+	live := NewTodoService()
+
+	done := make(chan *models.Todo, 10)
+	for i := 0; i < 10; i++ {
+		go func() {
+			done <- live.Create(models.TodoCreate{Text: "Concurrent Todo"})
+		}()
+	}
+	created := make([]*models.Todo, 0, 10)
+	for i := 0; i < 10; i++ {
+		created = append(created, <-done)
+	}
+
+	replayed := NewTodoService()
+
+	liveCount := len(live.GetAll("", "", ""))
+	replayedCount := len(replayed.GetAll("", "", ""))
+	if replayedCount != liveCount {
+		t.Fatalf("replayed service has %d todos, want %d (matching the live one)", replayedCount, liveCount)
+	}
+	for _, todo := range created {
+		if _, ok := replayed.GetByID(todo.ID); !ok {
+			t.Errorf("expected concurrently created todo %s to survive replay", todo.ID)
+		}
 	}
 }
 
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"spicytodo-go-api/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestStreamReceivesCreateEvent verifies that a subscriber connected to
+// GET /api/todos/stream receives a "TodoCreated" event for a subsequent
+// POST /api/todos, within a bounded timeout.
+func TestStreamReceivesCreateEvent(t *testing.T) {
+	router := setupIntegrationTest(t)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	req, err := http.NewRequest("GET", server.URL+"/api/todos/stream", nil)
+	if err != nil {
+		t.Fatalf("failed to build stream request: %v", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	createBody, _ := json.Marshal(models.TodoCreate{Text: "Streamed Todo"})
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		http.Post(server.URL+"/api/todos", "application/json", bytes.NewBuffer(createBody))
+	}()
+
+	result := make(chan string, 1)
+	go func() {
+		reader := bufio.NewReader(resp.Body)
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			if strings.HasPrefix(line, "event: TodoCreated") {
+				result <- line
+				return
+			}
+		}
+	}()
+
+	select {
+	case <-result:
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for TodoCreated event on stream")
+	}
+}
+
+// TestStreamPollModeReturnsBufferedEvents verifies that `?poll=1&since=0`
+// returns the events recorded so far as a single JSON array instead of
+// holding the connection open.
+func TestStreamPollModeReturnsBufferedEvents(t *testing.T) {
+	router := setupIntegrationTest(t)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	createBody, _ := json.Marshal(models.TodoCreate{Text: "Polled Todo"})
+	http.Post(server.URL+"/api/todos", "application/json", bytes.NewBuffer(createBody))
+
+	resp, err := http.Get(server.URL + "/api/todos/stream?poll=1&since=0")
+	if err != nil {
+		t.Fatalf("failed to poll stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.Header.Get("Content-Type") != "application/json; charset=utf-8" {
+		t.Errorf("poll response Content-Type = %q, want JSON", resp.Header.Get("Content-Type"))
+	}
+
+	var body struct {
+		Events []struct {
+			Seq  uint64 `json:"seq"`
+			Type string `json:"type"`
+		} `json:"events"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode poll response: %v", err)
+	}
+
+	found := false
+	for _, event := range body.Events {
+		if event.Type == "TodoCreated" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected a TodoCreated event in the polled batch")
+	}
+}
+
+// TestTopicStreamFiltersByTag verifies that GET /api/topics/:tag only
+// delivers events for todos carrying that tag, ignoring others.
+func TestTopicStreamFiltersByTag(t *testing.T) {
+	router := setupIntegrationTest(t)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	req, err := http.NewRequest("GET", server.URL+"/api/topics/work", nil)
+	if err != nil {
+		t.Fatalf("failed to build topic stream request: %v", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to open topic stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		otherBody, _ := json.Marshal(models.TodoCreate{Text: "Wrong tag", Tags: []string{"home"}})
+		http.Post(server.URL+"/api/todos", "application/json", bytes.NewBuffer(otherBody))
+
+		time.Sleep(50 * time.Millisecond)
+		matchBody, _ := json.Marshal(models.TodoCreate{Text: "Right tag", Tags: []string{"work"}})
+		http.Post(server.URL+"/api/todos", "application/json", bytes.NewBuffer(matchBody))
+	}()
+
+	result := make(chan string, 1)
+	go func() {
+		reader := bufio.NewReader(resp.Body)
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			if strings.HasPrefix(line, "data: ") {
+				result <- line
+				return
+			}
+		}
+	}()
+
+	select {
+	case line := <-result:
+		if !strings.Contains(line, "Right tag") {
+			t.Errorf("expected the first delivered event to be the tagged todo, got %q", line)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for a tagged event on the topic stream")
+	}
+}